@@ -2,14 +2,28 @@
 package asserting
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mkch/asserting/cond"
 )
 
 // TB is a wrapper of testing.TB to do assertion.
+//
+// Failures are reported at the line of the test function that calls Assert,
+// or calls a helper function which calls Assert, provided that every helper
+// function in between calls t.Helper() on entry, as with testing.TB.
+// The Helper method of TB is promoted from testing.TB, so it marks the caller
+// of t.Helper() as a helper function, even if TB is embedded in other types.
+// Do not define a Helper method which calls t.TB.Helper() in such types,
+// because that would mark the method itself, not its caller.
 type TB struct {
 	testing.TB
 }
@@ -19,26 +33,98 @@ func NewTB(t testing.TB) TB {
 	return TB{t}
 }
 
+// wrapper is a testing.TB wrapping another one, e.g. the TB returned by
+// TB.WithContext.
+type wrapper interface {
+	unwrap() testing.TB
+}
+
+// Unwrap returns the testing.TB which t is created from, unwrapping the TBs returned
+// by the methods of TB, such as WithContext and Require. The result can be passed to
+// helper functions taking a *testing.T, e.g.
+//
+//	checkServer(t.Unwrap().(*testing.T), addr)
+//
+// Failures reported by such helpers are attributed as described in the document of TB.
+func (t TB) Unwrap() testing.TB {
+	tb := t.TB
+	for {
+		switch w := tb.(type) {
+		case TB:
+			tb = w.TB
+		case wrapper:
+			tb = w.unwrap()
+		default:
+			return tb
+		}
+	}
+}
+
 // Assert asserts v meets the condition c.
 // If v does not meet c, the assertion fails and a failure message
-// is reported. See the document of cond.Cond.
+// is reported. See the document of cond.Cond and Renderer.
 func (t TB) Assert(v interface{}, c cond.Cond) {
+	t.Helper()
+	t.assert("", v, c)
+}
+
+// AssertNamed is like Assert, except that the assertion is named name, e.g.
+// "status code", which is included in the failure message, and Failure.Name, so
+// that failures in loops and table tests are identifiable.
+func (t TB) AssertNamed(name string, v interface{}, c cond.Cond) {
+	t.Helper()
+	t.assert(name, v, c)
+}
+
+func (t TB) assert(name string, v interface{}, c cond.Cond) {
 	t.Helper()
 	if err, ok := v.(*hasError); ok {
 		c := Equals(nil).SetMessage(err.message)
 		if err.fatal {
 			c.SetFatal()
 		}
-		t.Assert(0, c)
+		t.assert(name, 0, c)
 		return
 	}
-	if !c.Test(v) {
+	if truncation, ok := truncationOf(t.TB); ok {
+		defer overrideTruncation(truncation)()
+	}
+	// The failure message is computed once, and shared by the outputs.
+	ok, msg := cond.Check(c, v)
+	// Whether the failure stops the test, by the cond or by the TB.
+	fatal := cond.Fatal(c) || reportsErrorsFatally(t.TB)
+	record := !silent(t.Name())
+	if record {
+		recordStats(t, cond.Kind(c), !ok)
+		recordAssertion(t.Name(), name, c, v, msg, ok, fatal)
+	}
+	if !ok {
 		f := t.Error
 		if cond.Fatal(c) {
 			f = t.Fatal
 		}
-		f(cond.Message(c, v))
+		if record {
+			callHooks(t.Name(), name, c, v, msg, fatal)
+		}
+		f(render(name, c, v, msg))
+	}
+}
+
+// Assertf is like Assert, except that the failure message is replaced with
+// fmt.Sprintf(format, args...), see cond.Cond.SetMessagef.
+func (t TB) Assertf(v interface{}, c cond.Cond, format string, args ...interface{}) {
+	t.Helper()
+	t.Assert(v, c.SetMessagef(format, args...))
+}
+
+// Check returns whether v meets the condition c, without reporting anything,
+// so that callers can branch on the result, e.g. to skip the rest of a case.
+// A value returned by ValueError or ValueErrorFatal with a non-nil error meets no condition.
+func (t TB) Check(v interface{}, c cond.Cond) bool {
+	if _, ok := v.(*hasError); ok {
+		return false
 	}
+	return c.Test(v)
 }
 
 // AssertTrue asserts the condition is true.
@@ -50,7 +136,7 @@ func (t TB) AssertTrue(condition bool) {
 // AssertNoError asserts the err is nil.
 func (t TB) AssertNoError(err error) {
 	t.Helper()
-	t.Assert(err, Equals(nil).SetMessage(fmt.Sprintf("unexpected error <%v>", err)))
+	t.Assert(err, Equals(nil).SetMessage(msgf("unexpected error <%v>", err)))
 }
 
 // AssertEqual calls t.Assert(v, Equals(expected)).
@@ -104,7 +190,7 @@ type hasError struct {
 // 2. If err is nil, the code is executed the same way as TB.Assert(v, cond)
 func ValueError(v interface{}, err error) interface{} {
 	if err != nil {
-		return &hasError{message: fmt.Sprintf("unexpected error <%v>", err)}
+		return &hasError{message: msgf("unexpected error <%v>", err)}
 	}
 	return v
 }
@@ -113,52 +199,200 @@ func ValueError(v interface{}, err error) interface{} {
 // 1. If err is not nil, the assertion fails with t.Fatal("unexpected error ...").
 func ValueErrorFatal(v interface{}, err error) interface{} {
 	if err != nil {
-		return &hasError{message: fmt.Sprintf("unexpected error <%v>", err), fatal: true}
+		return &hasError{message: msgf("unexpected error <%v>", err), fatal: true}
 	}
 	return v
 }
 
 type equals struct {
 	expected interface{}
+	exact    bool // Whether to ignore Equal methods.
 }
 
 // Equals returns a cond which is true if a value equals to the expected value.
-// The equality is determined with operator ==.
+// If either value has an Equal method which accepts the other value and returns bool,
+// such as time.Time.Equal, the equality is determined with that method. So time.Time
+// values are equal if they represent the same instant, regardless of their locations
+// and monotonic clock readings.
+// Otherwise, the equality is determined with operator ==, except that byte slices
+// of the same type are compared with bytes.Equal.
+// The cond is always false if both values are of the same uncomparable type,
+// such as a map or a function.
+// If both values are structs of the same type, the failure message lists
+// the path of every differing field.
 func Equals(expected interface{}) cond.Cond {
 	return cond.New(&equals{expected: expected})
 }
 
+// EqualsExactly returns a cond which is like Equals, except that Equal methods are ignored.
+func EqualsExactly(expected interface{}) cond.Cond {
+	return cond.New(&equals{expected: expected, exact: true})
+}
+
 func (c *equals) Test(v interface{}) bool {
+	if !c.exact {
+		if eq, ok := callEqual(c.expected, v); ok {
+			return eq
+		}
+	}
+	if uncomparable(c.expected, v) != nil {
+		return false
+	}
 	return eq(c.expected, v)
 }
 
 func (c *equals) Message(v interface{}) string {
+	if t1, ok := c.expected.(time.Time); ok {
+		if t2, ok := v.(time.Time); ok {
+			return timeMsg("expected <%v> but was <%v>", t1, t2)
+		}
+	}
+	if !c.exact {
+		if _, ok := callEqual(c.expected, v); ok {
+			return formatMsg("expected <%v> but was <%v>", c.expected, v)
+		}
+	}
+	if t := uncomparable(c.expected, v); t != nil {
+		return uncomparableMsg(t)
+	}
+	if x, y, ok := byteSlices(c.expected, v); ok {
+		return hexdumpDiff(x, y)
+	}
+	if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Struct && t == reflect.TypeOf(c.expected) &&
+		!formatsWhole(reflect.ValueOf(v)) {
+		if d := diff(c.expected, v, c.exact); d != "" {
+			return d
+		}
+	}
 	return formatMsg("expected <%v> but was <%v>", c.expected, v)
 }
 
+func (c *equals) Kind() string {
+	if c.exact {
+		return "EqualsExactly"
+	}
+	return "Equals"
+}
+
+func (c *equals) Expected() interface{} {
+	return c.expected
+}
+
+type looseEquals equals
+
+// EqualsLoosely returns a cond which is like Equals, except that numbers of different
+// types are equal if they have the same mathematical value, as if expected were
+// wrapped with Numeric. For example, EqualsLoosely(5) is true for int64(5) and float32(5).
+func EqualsLoosely(expected interface{}) cond.Cond {
+	return cond.New(&looseEquals{expected: expected})
+}
+
+func (c *looseEquals) Test(v interface{}) bool {
+	if eq, ok := numberEquals(reflect.ValueOf(c.expected), reflect.ValueOf(v)); ok {
+		return eq
+	}
+	return ((*equals)(c)).Test(v)
+}
+
+func (c *looseEquals) Message(v interface{}) string {
+	return ((*equals)(c)).Message(v)
+}
+
+func (c *looseEquals) Kind() string {
+	return "EqualsLoosely"
+}
+
+func (c *looseEquals) Expected() interface{} {
+	return c.expected
+}
+
+type strictEquals equals
+
+// EqualsStrictly returns a cond which is like Equals, except that a nil interface value
+// only equals to a nil interface value. For example, EqualsStrictly(nil) is false for
+// an error interface value holding a nil pointer, while Equals(nil) is true.
+func EqualsStrictly(expected interface{}) cond.Cond {
+	return cond.New(&strictEquals{expected: expected})
+}
+
+func (c *strictEquals) Test(v interface{}) bool {
+	if c.expected == nil || v == nil {
+		return c.expected == nil && v == nil
+	}
+	return ((*equals)(c)).Test(v)
+}
+
+func (c *strictEquals) Message(v interface{}) string {
+	msg := ((*equals)(c)).Message(v)
+	if c.expected == nil && v != nil && equalsNil(v) {
+		msg += msgf(" (typed nil %T)", v)
+	} else if v == nil && c.expected != nil && equalsNil(c.expected) {
+		msg += msgf(" (expected typed nil %T)", c.expected)
+	}
+	return msg
+}
+
+func (c *strictEquals) Kind() string {
+	return "EqualsStrictly"
+}
+
+func (c *strictEquals) Expected() interface{} {
+	return c.expected
+}
+
 type notEquals equals
 
 // NotEquals returns a cond which is true if a value does not equal to the expected value.
 // The inequality is determined with operator !=
+// The cond is always false if both values are of the same uncomparable type,
+// such as a map or a function.
 func NotEquals(unexpected interface{}) cond.Cond {
 	return cond.New((*notEquals)(&notEquals{expected: unexpected}))
 }
 
 func (c *notEquals) Test(v interface{}) bool {
+	if eq, ok := callEqual(c.expected, v); ok {
+		return !eq
+	}
+	if uncomparable(c.expected, v) != nil {
+		return false
+	}
 	return !((*equals)(c)).Test(v)
 }
 
 func (c *notEquals) Message(v interface{}) string {
-	return fmt.Sprintf("unexpected <%v>", v)
+	if _, ok := callEqual(c.expected, v); ok {
+		return msgf("unexpected <%v>", formatValue(v))
+	}
+	if t := uncomparable(c.expected, v); t != nil {
+		return uncomparableMsg(t)
+	}
+	return msgf("unexpected <%v>", formatValue(v))
+}
+
+func (c *notEquals) Kind() string {
+	return "NotEquals"
+}
+
+func (c *notEquals) Expected() interface{} {
+	return c.expected
 }
 
 type matches struct {
-	f func(v interface{}) bool
+	f    func(v interface{}) bool
+	desc string
 }
 
 // Matches returns a cond which is true if a value passes the test of function f.
+// If f is a named function, the failure message includes the function name.
 func Matches(f func(v interface{}) bool) cond.Cond {
-	return cond.New(&matches{f: f})
+	return cond.New(&matches{f: f, desc: funcName(f)})
+}
+
+// MatchesDescribed is like Matches, except that the failure message includes desc,
+// which describes the property tested by f, e.g. "is even".
+func MatchesDescribed(f func(v interface{}) bool, desc string) cond.Cond {
+	return cond.New(&matches{f: f, desc: desc})
 }
 
 func (c *matches) Test(v interface{}) bool {
@@ -166,7 +400,32 @@ func (c *matches) Test(v interface{}) bool {
 }
 
 func (c *matches) Message(v interface{}) string {
-	return fmt.Sprintf("unexpected <%v>", v)
+	if c.desc != "" {
+		return msgf("unexpected <%v>, expected: %v", formatValue(v), c.desc)
+	}
+	return msgf("unexpected <%v>", formatValue(v))
+}
+
+func (c *matches) Kind() string {
+	return "Matches"
+}
+
+var anonymousFunc = regexp.MustCompile(`\.func\d+(\.\d+)*$`)
+
+// funcName returns the unqualified name of function f,
+// or an empty string if f is an anonymous function.
+func funcName(f interface{}) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	if anonymousFunc.MatchString(name) {
+		return ""
+	}
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = name[strings.Index(name, ".")+1:]
+	return strings.TrimSuffix(name, "-fm")
 }
 
 type panics struct {
@@ -175,8 +434,7 @@ type panics struct {
 }
 
 // Panics returns a cond which is true if the tested function panics with the expected value.
-// Test() panics if a the tested value is not of type func() when this kind of cond
-// is used.
+// The cond is false if the tested value is not of type func().
 func Panics(expected interface{}) cond.Cond {
 	return cond.New(&panics{expected: expected})
 }
@@ -184,7 +442,7 @@ func Panics(expected interface{}) cond.Cond {
 func (c *panics) Test(v interface{}) (result bool) {
 	f, ok := v.(func())
 	if !ok {
-		panic(fmt.Sprintf("<%v> is not a func()", v))
+		return false
 	}
 
 	defer func() {
@@ -198,6 +456,9 @@ func (c *panics) Test(v interface{}) (result bool) {
 }
 
 func (c *panics) Message(v interface{}) string {
+	if _, ok := v.(func()); !ok {
+		return notFuncMsg(v)
+	}
 	nilExplain := ""
 	if c.got == nil {
 		nilExplain = " (didn't panic?)"
@@ -205,6 +466,14 @@ func (c *panics) Message(v interface{}) string {
 	return formatMsg("expected to panic with <%v> but <%v>"+nilExplain, c.expected, c.got)
 }
 
+func (c *panics) Kind() string {
+	return "Panics"
+}
+
+func (c *panics) Expected() interface{} {
+	return c.expected
+}
+
 type panicMatches struct {
 	got interface{} // The actual recovered value.
 	f   func(interface{}) bool
@@ -212,8 +481,7 @@ type panicMatches struct {
 
 // PanicMatches returns a cond which is true if the tested function panics with a value that passes
 // the test of function f.
-// TB.Assert() panics if a the tested value is not of type func() when this kind of cond
-// is used.
+// The cond is false if the tested value is not of type func().
 func PanicMatches(f func(interface{}) bool) cond.Cond {
 	return cond.New(&panicMatches{f: f})
 }
@@ -221,7 +489,7 @@ func PanicMatches(f func(interface{}) bool) cond.Cond {
 func (c *panicMatches) Test(v interface{}) (result bool) {
 	f, ok := v.(func())
 	if !ok {
-		panic(fmt.Sprintf("<%v> is not a func()", v))
+		return false
 	}
 
 	defer func() {
@@ -235,11 +503,22 @@ func (c *panicMatches) Test(v interface{}) (result bool) {
 }
 
 func (c *panicMatches) Message(v interface{}) string {
+	if _, ok := v.(func()); !ok {
+		return notFuncMsg(v)
+	}
 	nilExplain := ""
 	if c.got == nil {
 		nilExplain = " (didn't panic?)"
 	}
-	return fmt.Sprintf("unexpected panic <%v>"+nilExplain, c.got)
+	return msgf("unexpected panic <%v>"+nilExplain, formatValue(c.got))
+}
+
+func (c *panicMatches) Kind() string {
+	return "PanicMatches"
+}
+
+func notFuncMsg(v interface{}) string {
+	return msgf("<%[1]v(%[1]T)> is not a func()", v)
 }
 
 type equalsSlice struct {
@@ -269,6 +548,10 @@ func (c *equalsSlice) Test(v interface{}) bool {
 		panic(fmt.Sprintf("testing2: <%[1]v(%[1]T)> is not a slice", c.expected))
 	}
 
+	if x, y, ok := byteSlices(v, c.expected); ok {
+		return bytes.Equal(x, y)
+	}
+
 	v1 := reflect.ValueOf(v)
 	v2 := reflect.ValueOf(c.expected)
 
@@ -298,48 +581,85 @@ func (c *equalsSlice) Test(v interface{}) bool {
 		return true
 	}
 
+	if v1.Len() != v2.Len() {
+		return false
+	}
+
 	return reflect.DeepEqual(v, c.expected)
 }
 
 func (c *equalsSlice) Message(v interface{}) string {
+	if x, y, ok := byteSlices(c.expected, v); ok {
+		return hexdumpDiff(x, y)
+	}
+	if l1, l2 := sliceLen(c.expected), sliceLen(v); l1 != l2 {
+		return msgf("length mismatch: expected %v but was %v (expected <%v> but was <%v>)",
+			l1, l2, formatValue(c.expected), formatValue(v))
+	}
 	return formatMsg("expected <%v> but was <%v>", c.expected, v)
 }
 
-type untypedInt int64
+func (c *equalsSlice) Kind() string {
+	return "EqualsSlice"
+}
 
-func (i untypedInt) equals(r interface{}) bool {
-	tr := reflect.TypeOf(r)
-	if tr == nil {
-		return false
+func (c *equalsSlice) Expected() interface{} {
+	return c.expected
+}
+
+type strictEqualsSlice equalsSlice
+
+// EqualsSliceStrictly returns a cond which is like EqualsSlice, except that
+// nil does not equal to empty slice.
+func EqualsSliceStrictly(expected interface{}) cond.Cond {
+	return cond.New(&strictEqualsSlice{expected: expected})
+}
+
+func (c *strictEqualsSlice) Test(v interface{}) bool {
+	return ((*equalsSlice)(c)).Test(v) && equalsNil(v) == equalsNil(c.expected)
+}
+
+func (c *strictEqualsSlice) Message(v interface{}) string {
+	if ((*equalsSlice)(c)).Test(v) {
+		if equalsNil(c.expected) {
+			return msgf("expected nil slice but was empty slice")
+		}
+		return msgf("expected empty slice but was nil slice")
 	}
-	switch tr.Kind() {
-	case reflect.Int:
-		fallthrough
-	case reflect.Int8:
-		fallthrough
-	case reflect.Int16:
-		fallthrough
-	case reflect.Int32:
-		fallthrough
-	case reflect.Int64:
-		return int64(i) == reflect.ValueOf(r).Int()
-	case reflect.Uint:
-		fallthrough
-	case reflect.Uint8:
-		fallthrough
-	case reflect.Uint16:
-		fallthrough
-	case reflect.Uint32:
-		fallthrough
-	case reflect.Uint64:
-		return int64(i) >= 0 && uint64(i) == reflect.ValueOf(r).Uint()
-	case reflect.Float32:
-		fallthrough
-	case reflect.Float64:
-		return float64(i) == reflect.ValueOf(r).Float()
-	default:
-		return false
+	return ((*equalsSlice)(c)).Message(v)
+}
+
+func (c *strictEqualsSlice) Kind() string {
+	return "EqualsSliceStrictly"
+}
+
+func (c *strictEqualsSlice) Expected() interface{} {
+	return c.expected
+}
+
+// byteSlices returns the content of a and b if they are byte slices of the same type.
+func byteSlices(a, b interface{}) (x, y []byte, ok bool) {
+	t := reflect.TypeOf(a)
+	if t == nil || t != reflect.TypeOf(b) ||
+		t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Uint8 {
+		return
+	}
+	return reflect.ValueOf(a).Bytes(), reflect.ValueOf(b).Bytes(), true
+}
+
+// sliceLen returns the length of slice v. A nil interface value has length 0.
+func sliceLen(v interface{}) int {
+	if v == nil {
+		return 0
 	}
+	return reflect.ValueOf(v).Len()
+}
+
+type untypedInt int64
+
+func (i untypedInt) equals(r interface{}) bool {
+	v := reflect.ValueOf(r)
+	return isRealNumber(v) && intEquals(int64(i), v)
 }
 
 // UntypedInt returns an untyped integer which equals other integer or float types
@@ -351,39 +671,8 @@ func UntypedInt(n int64) interface{} {
 type untypedUint uint64
 
 func (i untypedUint) equals(r interface{}) bool {
-	tr := reflect.TypeOf(r)
-	if tr == nil {
-		return false
-	}
-	switch tr.Kind() {
-	case reflect.Uint:
-		fallthrough
-	case reflect.Uint8:
-		fallthrough
-	case reflect.Uint16:
-		fallthrough
-	case reflect.Uint32:
-		fallthrough
-	case reflect.Uint64:
-		return uint64(i) == reflect.ValueOf(r).Uint()
-	case reflect.Int:
-		fallthrough
-	case reflect.Int8:
-		fallthrough
-	case reflect.Int16:
-		fallthrough
-	case reflect.Int32:
-		fallthrough
-	case reflect.Int64:
-		v := reflect.ValueOf(r).Int()
-		return v >= 0 && uint64(i) == uint64(v)
-	case reflect.Float32:
-		fallthrough
-	case reflect.Float64:
-		return float64(i) == reflect.ValueOf(r).Float()
-	default:
-		return false
-	}
+	v := reflect.ValueOf(r)
+	return isRealNumber(v) && uintEquals(uint64(i), v)
 }
 
 type ieq interface {
@@ -392,7 +681,15 @@ type ieq interface {
 
 // UntypedUint returns an untyped integer which is reported by Assert equal to
 // values of integer or float types if they have the same value.
-func UntypedUint(n int64) interface{} {
+func UntypedUint(n uint64) interface{} {
+	return untypedUint(n)
+}
+
+// UntypedUintInt64 is the int64 version of UntypedUint.
+//
+// Deprecated: UntypedUint used to take an int64, which made values greater than
+// math.MaxInt64 inexpressible. Use UntypedUint instead.
+func UntypedUintInt64(n int64) interface{} {
 	return untypedUint(n)
 }
 
@@ -464,6 +761,14 @@ func UntypedComplex(c complex128) interface{} {
 }
 
 func eq(a, b interface{}) bool {
+	if x, y, ok := byteSlices(a, b); ok {
+		return bytes.Equal(x, y)
+	}
+
+	if uncomparable(a, b) != nil {
+		return false
+	}
+
 	if a == b {
 		return true
 	}
@@ -487,6 +792,57 @@ func eq(a, b interface{}) bool {
 	return false
 }
 
+// callEqual calls a.Equal(b) or b.Equal(a), whichever is available.
+// An Equal method must accept a single argument that the other value is assignable to,
+// and return a bool. ok is false if neither a nor b has such a method.
+func callEqual(a, b interface{}) (eq bool, ok bool) {
+	if eq, ok = callEqualMethod(a, b); ok {
+		return
+	}
+	return callEqualMethod(b, a)
+}
+
+func callEqualMethod(a, b interface{}) (eq bool, ok bool) {
+	if a == nil || b == nil || equalsNil(a) {
+		return false, false
+	}
+	m := reflect.ValueOf(a).MethodByName("Equal")
+	if !m.IsValid() {
+		return false, false
+	}
+	t := m.Type()
+	if t.NumIn() != 1 || t.IsVariadic() || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Bool ||
+		!reflect.TypeOf(b).AssignableTo(t.In(0)) {
+		return false, false
+	}
+	return m.Call([]reflect.Value{reflect.ValueOf(b)})[0].Bool(), true
+}
+
+// uncomparable returns the type of a and b if they are of the same type, and can't
+// be compared with operator ==, nil otherwise. Values of comparable types may still
+// be uncomparable, e.g. structs with interface fields holding slices.
+// Byte slices are compared with bytes.Equal, so they are not uncomparable.
+func uncomparable(a, b interface{}) reflect.Type {
+	t := reflect.TypeOf(a)
+	if t == nil || t != reflect.TypeOf(b) || reflect.ValueOf(a).Comparable() && reflect.ValueOf(b).Comparable() {
+		return nil
+	}
+	if _, _, ok := byteSlices(a, b); ok {
+		return nil
+	}
+	return t
+}
+
+func uncomparableMsg(t reflect.Type) string {
+	if t.Kind() == reflect.Slice {
+		return msgf("type %v is not comparable; use EqualsSlice", t)
+	}
+	if t.Kind() == reflect.Func {
+		return msgf("type %v is not comparable; use Matches", t)
+	}
+	return msgf("type %v is not comparable; use DeepEquals", t)
+}
+
 // equalsNil tests whether v is a nil interface value or the value of v == nil.
 func equalsNil(v interface{}) bool {
 	t := reflect.TypeOf(v)
@@ -514,11 +870,99 @@ func equalsNil(v interface{}) bool {
 }
 
 func formatMsg(format string, arg1, arg2 interface{}) string {
-	str1, str2 := fmt.Sprintf("%v", arg1), fmt.Sprintf("%v", arg2)
+	str1, str2, ok := formatStrings(arg1, arg2)
+	if !ok {
+		str1, str2 = formatValue(arg1), formatValue(arg2)
+	}
+	if str1 == str2 && reflect.TypeOf(arg1) == reflect.TypeOf(arg2) {
+		// Same type, so elements must differ in type.
+		str1, str2 = formatTyped(reflect.ValueOf(arg1), 0), formatTyped(reflect.ValueOf(arg2), 0)
+	}
 	if str1 == str2 {
-		arg1, arg2 = fmt.Sprintf("%[1]v(%[1]T)", arg1), fmt.Sprintf("%[1]v(%[1]T)", arg2)
+		arg1, arg2 = fmt.Sprintf("%v(%T)", formatValue(arg1), arg1), fmt.Sprintf("%v(%T)", formatValue(arg2), arg2)
 	} else {
 		arg1, arg2 = str1, str2
 	}
-	return fmt.Sprintf(format, arg1, arg2)
+	return msgf(format, arg1, arg2)
+}
+
+// formatValue formats v with the formatter registered with RegisterFormatter,
+// or %v, or %q if v is a string, so that spaces and invisible characters can be seen.
+// Values not formatted by registered formatters are truncated, see SetTruncation.
+func formatValue(v interface{}) string {
+	if str, ok := customFormat(v); ok {
+		return str
+	}
+	return formatTruncated(v)
+}
+
+// maxTypedDepth is the max depth of nested values formatted by formatTyped.
+const maxTypedDepth = 10
+
+// formatTyped formats v like %v, but with the type of every leaf value,
+// so that values of different element types can be told apart.
+func formatTyped(v reflect.Value, depth int) string {
+	if depth > maxTypedDepth {
+		return "..."
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		return "<nil>"
+	case reflect.Interface:
+		if v.IsNil() {
+			return fmt.Sprintf("<nil>(%v)", v.Type())
+		}
+		return formatTyped(v.Elem(), depth+1)
+	case reflect.Ptr:
+		if v.IsNil() || formatsWhole(v) {
+			break
+		}
+		return "&" + formatTyped(v.Elem(), depth+1)
+	case reflect.Slice, reflect.Array:
+		if formatsWhole(v) {
+			break
+		}
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elems[i] = formatTyped(v.Index(i), depth+1)
+		}
+		return "[" + strings.Join(elems, " ") + "]"
+	case reflect.Map:
+		if formatsWhole(v) {
+			break
+		}
+		elems := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			elems = append(elems, formatTyped(k, depth+1)+":"+formatTyped(v.MapIndex(k), depth+1))
+		}
+		sort.Strings(elems)
+		return "map[" + strings.Join(elems, " ") + "]"
+	case reflect.Struct:
+		if formatsWhole(v) {
+			break
+		}
+		fields := make([]string, v.NumField())
+		for i := range fields {
+			fields[i] = formatTyped(v.Field(i), depth+1)
+		}
+		return "{" + strings.Join(fields, " ") + "}"
+	}
+	return fmt.Sprintf("%v(%v)", formatLeaf(v), v.Type())
+}
+
+var (
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// formatsWhole returns whether v is formatted as a whole, with a formatter
+// registered with RegisterFormatter or with its String or Error method.
+func formatsWhole(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+	if _, ok := formatters.Load(v.Type()); ok {
+		return true
+	}
+	return v.Type().Implements(stringerType) || v.Type().Implements(errorType)
 }
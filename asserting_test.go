@@ -2,6 +2,8 @@ package asserting_test
 
 import (
 	"errors"
+	"math"
+	"strings"
 	"testing"
 	"unsafe"
 
@@ -88,7 +90,7 @@ func TestMatches(t1 *testing.T) {
 		len(mock.ErrorMessages[0]) != 1 ||
 		len(mock.ErrorMessages[1]) != 1 ||
 		mock.ErrorMessages[0][0] != "unexpected <1>" ||
-		mock.ErrorMessages[1][0] != "unexpected <abc>" {
+		mock.ErrorMessages[1][0] != `unexpected <"abc">` {
 		t1.Fatal(mock.ErrorMessages)
 	}
 }
@@ -193,7 +195,23 @@ func TestEqualsSlice(t1 *testing.T) {
 	}
 	if len(mock.ErrorMessages) != 1 ||
 		len(mock.ErrorMessages[0]) != 1 ||
-		mock.ErrorMessages[0][0] != "expected <[1 2]> but was <[1 2 3]>" {
+		mock.ErrorMessages[0][0] != "length mismatch: expected 2 but was 3 (expected <[1 2]> but was <[1 2 3]>)" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	mock.ErrorMessages = nil
+	t.Assert([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, EqualsSlice(nil))
+	if len(mock.ErrorMessages) != 1 ||
+		len(mock.ErrorMessages[0]) != 1 ||
+		mock.ErrorMessages[0][0] != "length mismatch: expected 0 but was 12 (expected <<nil>> but was <[1 2 3 4 5 6 7 8 9 10 11 12]>)" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	mock.ErrorMessages = nil
+	t.Assert([]int{1, 2, 4}, EqualsSlice([]int{1, 2, 3}))
+	if len(mock.ErrorMessages) != 1 ||
+		len(mock.ErrorMessages[0]) != 1 ||
+		mock.ErrorMessages[0][0] != "expected <[1 2 3]> but was <[1 2 4]>" {
 		t1.Fatal(mock.ErrorMessages)
 	}
 }
@@ -222,6 +240,47 @@ func TestValueError(t1 *testing.T) {
 	}
 }
 
+func TestAssertf(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := TB{mock}
+
+	formatted := false
+	t.Assertf(1, Equals(1), "%v", stringerFunc(func() string { formatted = true; return "" }))
+	if formatted || len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	for i := 0; i < 2; i++ {
+		t.Assertf(i, Equals(2), "case %v: %q", i, "x")
+	}
+	t.Assert(3, Equals(2).SetMessagef("case %v", 3).SetFatal())
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != `case 0: "x"` ||
+		mock.ErrorMessages[1][0] != `case 1: "x"` ||
+		len(mock.FatalMessages) != 1 || mock.FatalMessages[0][0] != "case 3" {
+		t1.Fatal(mock.ErrorMessages, mock.FatalMessages)
+	}
+}
+
+type stringerFunc func() string
+
+func (f stringerFunc) String() string { return f() }
+
+func TestCheck(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := TB{mock}
+
+	if !t.Check(1, Equals(1)) ||
+		t.Check(1, Equals(2)) ||
+		t.Check(1, Equals(2).SetFatal()) ||
+		t.Check(ValueError(1, errors.New("error")), Equals(1)) {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages, mock.FatalMessages)
+	}
+}
+
 func TestFatal(t1 *testing.T) {
 	mock := &MockTB{TB: t1}
 	t := TB{mock}
@@ -753,7 +812,259 @@ func TestAssertUntyped(t1 *testing.T) {
 	}
 	if len(mock.ErrorMessages) != 1 ||
 		len(mock.ErrorMessages[0]) != 1 ||
-		mock.ErrorMessages[0][0] != "expected <def> but was <abc>" {
+		mock.ErrorMessages[0][0] != `expected <"def"> but was <"abc">` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestEqualsBytes(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert([]byte("abc"), Equals([]byte("abc")))
+	t.Assert([]byte{}, Equals([]byte(nil)))
+	t.Assert([]byte("abc"), EqualsSlice([]byte("abc")))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]byte("abd"), Equals([]byte("abc")))
+	t.Assert([]byte("abd"), EqualsSlice([]byte("abc")))
+	t.Assert(make([]byte, 40), EqualsSlice([]byte("abc")))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	abc := "bytes differ at offset 2 (expected length 3 but was 3)\n" +
+		"offset    expected                                                            actual\n" +
+		"00000000  61 62 63                                        |abc             |  61 62 64                                        |abd             |"
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != abc ||
+		mock.ErrorMessages[1][0] != abc ||
+		!strings.HasPrefix(mock.ErrorMessages[2][0].(string), "bytes differ at offset 0 (expected length 3 but was 40)\n") {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestUntypedUintMax(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(uint64(math.MaxUint64), Equals(UntypedUint(math.MaxUint64)))
+	t.Assert(uint64(100), Equals(UntypedUintInt64(100)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(int64(-1), Equals(UntypedUint(math.MaxUint64)))
+	if len(mock.ErrorMessages) != 1 ||
+		mock.ErrorMessages[0][0] != "expected <18446744073709551615> but was <-1>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestEqualsUncomparable(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert([]int(nil), Equals(nil))
+	t.Assert([]int{1}, NotEquals(map[int]int{}))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{1}, Equals([]int{1}))
+	t.Assert(map[int]int{}, NotEquals(map[int]int{1: 1}))
+	t.Assert(func() {}, Equals(func() {}))
+	t.Assert(holder{X: []int{1}}, Equals(holder{X: []int{1}}))
+	t.Assert([1]interface{}{[]int{1}}, Equals([1]interface{}{[]int{1}}))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != "type []int is not comparable; use EqualsSlice" ||
+		mock.ErrorMessages[1][0] != "type map[int]int is not comparable; use DeepEquals" ||
+		mock.ErrorMessages[2][0] != "type func() is not comparable; use Matches" ||
+		mock.ErrorMessages[3][0] != "type asserting_test.holder is not comparable; use DeepEquals" ||
+		mock.ErrorMessages[4][0] != "type [1]interface {} is not comparable; use DeepEquals" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type holder struct {
+	X interface{}
+}
+
+type nilError struct{}
+
+func (*nilError) Error() string { return "nilError" }
+
+func TestEqualsStrictly(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	var err error = (*nilError)(nil)
+	t.Assert(nil, EqualsStrictly(nil))
+	t.Assert((error)(nil), EqualsStrictly(nil))
+	t.Assert((*int)(nil), EqualsStrictly((*int)(nil)))
+	t.Assert(1, EqualsStrictly(1))
+	t.Assert(err, Equals(nil))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(err, EqualsStrictly(nil))
+	t.Assert(nil, EqualsStrictly((*int)(nil)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected <<nil>> but was <nilError> (typed nil *asserting_test.nilError)" ||
+		mock.ErrorMessages[1][0] != "expected <<nil>(*int)> but was <<nil>(<nil>)> (expected typed nil *int)" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestEqualsSliceStrictly(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(nil, EqualsSliceStrictly([]int(nil)))
+	t.Assert([]int{}, EqualsSliceStrictly([]int{}))
+	t.Assert([]int{1}, EqualsSliceStrictly([]int{1}))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{}, EqualsSliceStrictly(nil))
+	t.Assert([]int(nil), EqualsSliceStrictly([]int{}))
+	t.Assert([]int{1}, EqualsSliceStrictly([]int{2}))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "expected nil slice but was empty slice" ||
+		mock.ErrorMessages[1][0] != "expected empty slice but was nil slice" ||
+		mock.ErrorMessages[2][0] != "expected <[2]> but was <[1]>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestQuotedStrings(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert("a", Equals("a "))
+	t.Assert("a\t", NotEquals("a\t"))
+	t.Assert(func() { panic("a\n") }, Panics("a"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != `expected <"a "> but was <"a">` ||
+		mock.ErrorMessages[1][0] != `unexpected <"a\t">` ||
+		mock.ErrorMessages[2][0] != `expected to panic with <"a"> but <"a\n">` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestTypeAnnotation(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	type point struct {
+		X, Y interface{}
+	}
+
+	t.Assert([]interface{}{1, int64(2)}, EqualsSlice([]interface{}{1, 2}))
+	t.Assert(point{1, "a"}, Equals(point{int8(1), "a"}))
+	t.Assert(map[string]interface{}{"a": 1}, NotEquals(1))
+	t.Assert(&point{1, 2}, Equals(&point{1, 2}))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "expected <[1(int) 2(int)]> but was <[1(int) 2(int64)]>" ||
+		mock.ErrorMessages[1][0] != "point.X: expected <1(int8)> but was <1(int)>" ||
+		mock.ErrorMessages[2][0] != "expected <&{1 2}(*asserting_test.point)> but was <&{1 2}(*asserting_test.point)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestPanicsNotFunc(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(1, Panics(1))
+	t.Assert(func() int { return 1 }, PanicMatches(func(interface{}) bool { return true }))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "<1(int)> is not a func()" ||
+		!strings.HasSuffix(mock.ErrorMessages[1][0].(string), "(func() int)> is not a func()") {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func isEven(v interface{}) bool {
+	return v.(int)%2 == 0
+}
+
+func TestMatchesDescription(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(2, Matches(isEven))
+	t.Assert(2, MatchesDescribed(isEven, "is even"))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(1333, Matches(isEven))
+	t.Assert(1333, MatchesDescribed(isEven, "is even"))
+	t.Assert(1333, MatchesDescribed(func(v interface{}) bool { return v == 0 }, "is zero"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "unexpected <1333>, expected: isEven" ||
+		mock.ErrorMessages[1][0] != "unexpected <1333>, expected: is even" ||
+		mock.ErrorMessages[2][0] != "unexpected <1333>, expected: is zero" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type money struct {
+	cents    int64
+	currency string
+}
+
+func (m money) Equal(other money) bool {
+	return m.cents == other.cents && strings.EqualFold(m.currency, other.currency)
+}
+
+func TestEqualMethod(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(money{100, "usd"}, Equals(money{100, "USD"}))
+	t.Assert(money{100, "usd"}, NotEquals(money{200, "USD"}))
+	t.Assert(money{100, "usd"}, EqualsExactly(money{100, "usd"}))
+	t.Assert(money{100, "usd"}, NotEquals(100))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(money{100, "usd"}, Equals(money{200, "USD"}))
+	t.Assert(money{100, "usd"}, NotEquals(money{100, "USD"}))
+	t.Assert(money{100, "usd"}, EqualsExactly(money{100, "USD"}))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "expected <{200 USD}> but was <{100 usd}>" ||
+		mock.ErrorMessages[1][0] != "unexpected <{100 usd}>" ||
+		mock.ErrorMessages[2][0] != `money.currency: expected <"USD"> but was <"usd">` {
 		t1.Fatal(mock.ErrorMessages)
 	}
 }
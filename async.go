@@ -0,0 +1,127 @@
+package asserting
+
+import (
+	"time"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type eventually struct {
+	c                 cond.Cond
+	timeout, interval time.Duration
+	msg               string // The failure message of the last Test.
+}
+
+// Eventually returns a cond which is true if the value returned by the tested
+// func() interface{} meets c within timeout. The func is called every interval, until
+// the value meets c or timeout expires. The failure message includes the failure
+// message of c for the last value.
+// The cond is false if the tested value is not of type func() interface{}.
+func Eventually(c cond.Cond, timeout, interval time.Duration) cond.Cond {
+	return cond.New(&eventually{c: c, timeout: timeout, interval: interval})
+}
+
+func (c *eventually) Test(v interface{}) bool {
+	f, ok := v.(func() interface{})
+	if !ok {
+		c.msg = msgf("<%[1]v(%[1]T)> is not a func() interface{}", v)
+		return false
+	}
+	deadline := time.Now().Add(c.timeout)
+	for attempts := 1; ; attempts++ {
+		value := f()
+		if c.c.Test(value) {
+			c.msg = ""
+			return true
+		}
+		if !time.Now().Add(c.interval).Before(deadline) {
+			c.msg = msgf("not met within %v (%v attempts), last: %v", c.timeout, attempts, cond.Message(c.c, value))
+			return false
+		}
+		time.Sleep(c.interval)
+	}
+}
+
+func (c *eventually) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *eventually) Kind() string {
+	return "Eventually"
+}
+
+// AssertEventually calls t.Assert(f, Eventually(c, timeout, interval)).
+func (t TB) AssertEventually(f func() interface{}, c cond.Cond, timeout, interval time.Duration) {
+	t.Helper()
+	t.Assert(f, Eventually(c, timeout, interval))
+}
+
+type consistently struct {
+	c                  cond.Cond
+	duration, interval time.Duration
+	never              bool // Whether c must never be met, instead of always.
+	msg                string
+}
+
+// Consistently returns a cond which is true if the value returned by the tested
+// func() interface{} meets c every time the func is called, every interval until
+// duration elapses. It fails as soon as the value doesn't meet c.
+// The cond is false if the tested value is not of type func() interface{}.
+func Consistently(c cond.Cond, duration, interval time.Duration) cond.Cond {
+	return cond.New(&consistently{c: c, duration: duration, interval: interval})
+}
+
+// Never returns a cond which is like Consistently, except that the value must never
+// meet c, e.g. to verify the absence of a spurious event.
+func Never(c cond.Cond, duration, interval time.Duration) cond.Cond {
+	return cond.New(&consistently{c: c, duration: duration, interval: interval, never: true})
+}
+
+func (c *consistently) Test(v interface{}) bool {
+	f, ok := v.(func() interface{})
+	if !ok {
+		c.msg = msgf("<%[1]v(%[1]T)> is not a func() interface{}", v)
+		return false
+	}
+	start := time.Now()
+	deadline := start.Add(c.duration)
+	for {
+		value := f()
+		if c.c.Test(value) == c.never {
+			if c.never {
+				c.msg = msgf("unexpectedly met after %v: <%v>", time.Since(start).Round(time.Millisecond), formatValue(value))
+			} else {
+				c.msg = msgf("not met after %v: %v", time.Since(start).Round(time.Millisecond), cond.Message(c.c, value))
+			}
+			return false
+		}
+		if !time.Now().Add(c.interval).Before(deadline) {
+			c.msg = ""
+			return true
+		}
+		time.Sleep(c.interval)
+	}
+}
+
+func (c *consistently) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *consistently) Kind() string {
+	if c.never {
+		return "Never"
+	}
+	return "Consistently"
+}
+
+// AssertConsistently calls t.Assert(f, Consistently(c, duration, interval)).
+func (t TB) AssertConsistently(f func() interface{}, c cond.Cond, duration, interval time.Duration) {
+	t.Helper()
+	t.Assert(f, Consistently(c, duration, interval))
+}
+
+// AssertNever calls t.Assert(f, Never(c, duration, interval)).
+func (t TB) AssertNever(f func() interface{}, c cond.Cond, duration, interval time.Duration) {
+	t.Helper()
+	t.Assert(f, Never(c, duration, interval))
+}
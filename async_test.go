@@ -0,0 +1,65 @@
+package asserting_test
+
+import (
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestAssertEventually(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	var n int32
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		atomic.StoreInt32(&n, 1)
+	}()
+	t.AssertEventually(func() interface{} { return atomic.LoadInt32(&n) }, Equals(int32(1)), time.Second, time.Millisecond)
+	t.AssertEventually(func() interface{} { return 1 }, Equals(1), 0, time.Millisecond)
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	calls := 0
+	t.AssertEventually(func() interface{} { calls++; return calls }, Equals(0), 50*time.Millisecond, 20*time.Millisecond)
+	t.Assert(1, Eventually(Equals(1), time.Second, time.Millisecond))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		!regexp.MustCompile(`^not met within 50ms \((\d+) attempts\), last: expected <0> but was <\d+>$`).MatchString(mock.ErrorMessages[0][0].(string)) ||
+		mock.ErrorMessages[1][0] != "<1(int)> is not a func() interface{}" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestAssertConsistently(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	calls := 0
+	t.AssertConsistently(func() interface{} { calls++; return calls }, GreaterThan(0), 20*time.Millisecond, time.Millisecond)
+	t.AssertNever(func() interface{} { return calls }, Equals(0), 20*time.Millisecond, time.Millisecond)
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 || calls < 2 {
+		t1.Fatal(mock.ErrorMessages, calls)
+	}
+
+	calls = 0
+	t.AssertConsistently(func() interface{} { calls++; return calls }, LessThan(3), time.Second, time.Millisecond)
+	calls = 0
+	t.AssertNever(func() interface{} { calls++; return calls }, Equals(3), time.Second, time.Millisecond)
+	t.Assert(1, Never(Equals(1), time.Second, time.Millisecond))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		!regexp.MustCompile(`^not met after \d+ms: <3> is not less than <3>$`).MatchString(mock.ErrorMessages[0][0].(string)) ||
+		!regexp.MustCompile(`^unexpectedly met after \d+ms: <3>$`).MatchString(mock.ErrorMessages[1][0].(string)) ||
+		mock.ErrorMessages[2][0] != "<1(int)> is not a func() interface{}" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
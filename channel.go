@@ -0,0 +1,97 @@
+package asserting
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type receives struct {
+	c       cond.Cond // Nil if any value is accepted.
+	timeout time.Duration
+	none    bool   // Whether nothing is expected to be received.
+	msg     string // The failure message of the last Test.
+}
+
+// Receives returns a cond which is true if a value is received from the tested channel
+// within timeout, and the value meets c. A nil c accepts any value.
+// The cond is false if the channel is closed, or the tested value is not a channel
+// which can be received from. Note that testing the cond consumes the received value.
+func Receives(c cond.Cond, timeout time.Duration) cond.Cond {
+	return cond.New(&receives{c: c, timeout: timeout})
+}
+
+// DoesNotReceive returns a cond which is true if nothing is received from the tested
+// channel within timeout, and the channel is not closed. See Receives.
+func DoesNotReceive(timeout time.Duration) cond.Cond {
+	return cond.New(&receives{timeout: timeout, none: true})
+}
+
+// receive receives from the tested channel v until timeout expires.
+// msg is not empty if v is not a channel which can be received from.
+func (c *receives) receive(v interface{}) (value interface{}, ok, timedOut bool, msg string) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Chan || rv.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil, false, false, msgf("<%[1]v(%[1]T)> is not a channel which can be received from", v)
+	}
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	chosen, recv, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: rv},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	})
+	if chosen == 1 {
+		return nil, false, true, ""
+	}
+	return recv.Interface(), ok, false, ""
+}
+
+func (c *receives) Test(v interface{}) bool {
+	c.msg = c.Check(v)
+	return c.msg == ""
+}
+
+func (c *receives) Check(v interface{}) string {
+	value, ok, timedOut, msg := c.receive(v)
+	switch {
+	case msg != "":
+		return msg
+	case c.none && timedOut:
+		return ""
+	case c.none && ok:
+		return msgf("unexpectedly received <%v>", formatValue(value))
+	case timedOut:
+		return msgf("nothing received within %v", c.timeout)
+	case !ok:
+		return msgf("channel closed")
+	case c.c != nil:
+		if ok, msg := cond.Check(c.c, value); !ok {
+			return msgf("received <%v>: %v", formatValue(value), msg)
+		}
+	}
+	return ""
+}
+
+func (c *receives) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *receives) Kind() string {
+	if c.none {
+		return "DoesNotReceive"
+	}
+	return "Receives"
+}
+
+// AssertReceives calls t.Assert(ch, Receives(c, timeout)).
+func (t TB) AssertReceives(ch interface{}, c cond.Cond, timeout time.Duration) {
+	t.Helper()
+	t.Assert(ch, Receives(c, timeout))
+}
+
+// AssertDoesNotReceive calls t.Assert(ch, DoesNotReceive(timeout)).
+func (t TB) AssertDoesNotReceive(ch interface{}, timeout time.Duration) {
+	t.Helper()
+	t.Assert(ch, DoesNotReceive(timeout))
+}
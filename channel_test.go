@@ -0,0 +1,45 @@
+package asserting_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestAssertReceives(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	ch := make(chan int, 1)
+	go func() { time.Sleep(10 * time.Millisecond); ch <- 1 }()
+	t.AssertReceives(ch, Equals(1), time.Second)
+	ch <- 2
+	t.AssertReceives((<-chan int)(ch), nil, time.Second)
+	t.AssertDoesNotReceive(ch, 10*time.Millisecond)
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	ch <- 3
+	t.AssertReceives(ch, Equals(1), time.Second)
+	t.AssertReceives(ch, nil, 10*time.Millisecond)
+	ch <- 4
+	t.AssertDoesNotReceive(ch, time.Second)
+	close(ch)
+	t.AssertReceives(ch, nil, time.Second)
+	t.AssertDoesNotReceive(ch, time.Second)
+	t.Assert((chan<- int)(nil), Receives(nil, time.Second))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 6 ||
+		mock.ErrorMessages[0][0] != "received <3>: expected <1> but was <3>" ||
+		mock.ErrorMessages[1][0] != "nothing received within 10ms" ||
+		mock.ErrorMessages[2][0] != "unexpectedly received <4>" ||
+		mock.ErrorMessages[3][0] != "channel closed" ||
+		mock.ErrorMessages[4][0] != "channel closed" ||
+		mock.ErrorMessages[5][0] != "<<nil>(chan<- int)> is not a channel which can be received from" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
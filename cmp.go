@@ -0,0 +1,38 @@
+package asserting
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/mkch/asserting/cond"
+)
+
+type equalsCmp struct {
+	expected interface{}
+	opts     []cmp.Option
+}
+
+// EqualsCmp returns a cond which is true if cmp.Equal(expected, v, opts...) returns true,
+// where v is the tested value. Options such as cmpopts.IgnoreFields and cmp.Comparer
+// customize the comparison, see package github.com/google/go-cmp/cmp.
+// The failure message is the output of cmp.Diff, in which "-" lines are expected
+// and "+" lines are actual.
+// Like cmp.Equal, Test panics if an unexported field is compared without an option
+// handling it.
+func EqualsCmp(expected interface{}, opts ...cmp.Option) cond.Cond {
+	return cond.New(&equalsCmp{expected: expected, opts: opts})
+}
+
+func (c *equalsCmp) Test(v interface{}) bool {
+	return cmp.Equal(c.expected, v, c.opts...)
+}
+
+func (c *equalsCmp) Message(v interface{}) string {
+	return msgf("mismatch (-expected +actual):\n%v", cmp.Diff(c.expected, v, c.opts...))
+}
+
+func (c *equalsCmp) Kind() string {
+	return "EqualsCmp"
+}
+
+func (c *equalsCmp) Expected() interface{} {
+	return c.expected
+}
@@ -0,0 +1,41 @@
+package asserting_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	. "github.com/mkch/asserting"
+)
+
+type user struct {
+	Name    string
+	Age     int
+	Updated int64
+}
+
+func TestEqualsCmp(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(user{"a", 1, 100}, EqualsCmp(user{"a", 1, 200}, cmpopts.IgnoreFields(user{}, "Updated")))
+	t.Assert("ABC", EqualsCmp("abc", cmp.Comparer(strings.EqualFold)))
+	t.Assert([]int{}, EqualsCmp([]int(nil), cmpopts.EquateEmpty()))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(user{"a", 2, 100}, EqualsCmp(user{"a", 1, 200}, cmpopts.IgnoreFields(user{}, "Updated")))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 1 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+	msg := mock.ErrorMessages[0][0].(string)
+	if !strings.HasPrefix(msg, "mismatch (-expected +actual):\n") ||
+		!strings.Contains(msg, "Age:") || strings.Contains(msg, "Updated") {
+		t1.Fatal(msg)
+	}
+}
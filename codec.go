@@ -0,0 +1,191 @@
+package asserting
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// GobMarshal returns the gob encoding of v.
+func GobMarshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobUnmarshal decodes the gob encoded data and stores the result in the value pointed to by v.
+func GobUnmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+type roundTrips struct {
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(data []byte, v interface{}) error
+}
+
+// RoundTrips returns a cond which is true if encoding a value with marshal, and then
+// decoding the result with unmarshal into a new value of the same type, reproduces
+// a value deeply equal to the original one. Equal methods are used if available,
+// see Equals. The failure message lists the differing fields.
+// Functions such as json.Marshal and json.Unmarshal can be used as marshal and unmarshal.
+func RoundTrips(marshal func(v interface{}) ([]byte, error), unmarshal func(data []byte, v interface{}) error) cond.Cond {
+	return cond.New(&roundTrips{marshal: marshal, unmarshal: unmarshal})
+}
+
+func (c *roundTrips) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *roundTrips) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *roundTrips) Check(v interface{}) string {
+	_, msg := c.roundTrip(v)
+	return msg
+}
+
+func (c *roundTrips) Kind() string {
+	return "RoundTrips"
+}
+
+// roundTrip encodes and decodes v, and returns the decoded value and the failure message.
+// The message is empty if the decoded value equals to v.
+func (c *roundTrips) roundTrip(v interface{}) (decoded interface{}, msg string) {
+	if v == nil {
+		return nil, "can't round trip nil"
+	}
+	data, err := c.marshal(v)
+	if err != nil {
+		return nil, msgf("unexpected marshal error <%v>", err)
+	}
+	p := reflect.New(reflect.TypeOf(v))
+	if err = c.unmarshal(data, p.Interface()); err != nil {
+		return nil, msgf("unexpected unmarshal error <%v>", err)
+	}
+	decoded = p.Elem().Interface()
+	if deepEquals(v, decoded) {
+		return decoded, ""
+	}
+	d := diff(v, decoded, false)
+	if d == "" {
+		d = formatMsg("expected <%v> but was <%v>", v, decoded)
+	}
+	return decoded, msgf("round trip mismatch:\n%v", d)
+}
+
+// AssertRoundTrip calls t.Assert(v, RoundTrips(marshal, unmarshal)).
+func (t TB) AssertRoundTrip(v interface{}, marshal func(v interface{}) ([]byte, error), unmarshal func(data []byte, v interface{}) error) {
+	t.Helper()
+	t.Assert(v, RoundTrips(marshal, unmarshal))
+}
+
+// AssertJSONRoundTrip calls t.AssertRoundTrip(v, json.Marshal, json.Unmarshal).
+func (t TB) AssertJSONRoundTrip(v interface{}) {
+	t.Helper()
+	t.AssertRoundTrip(v, json.Marshal, json.Unmarshal)
+}
+
+// AssertXMLRoundTrip calls t.AssertRoundTrip(v, xml.Marshal, xml.Unmarshal).
+func (t TB) AssertXMLRoundTrip(v interface{}) {
+	t.Helper()
+	t.AssertRoundTrip(v, xml.Marshal, xml.Unmarshal)
+}
+
+// AssertGobRoundTrip calls t.AssertRoundTrip(v, GobMarshal, GobUnmarshal).
+func (t TB) AssertGobRoundTrip(v interface{}) {
+	t.Helper()
+	t.AssertRoundTrip(v, GobMarshal, GobUnmarshal)
+}
+
+type stableJSON struct {
+	msg string // The failure message of the last Test.
+}
+
+// HasStableJSON returns a cond which is true if marshaling a value with json.Marshal
+// twice, and marshaling the value decoded from the result into a new value of the same type,
+// produce byte-identical output.
+func HasStableJSON() cond.Cond {
+	return cond.New(&stableJSON{})
+}
+
+func (c *stableJSON) Test(v interface{}) bool {
+	c.msg = c.Check(v)
+	return c.msg == ""
+}
+
+func (c *stableJSON) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *stableJSON) Kind() string {
+	return "HasStableJSON"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *stableJSON) Check(v interface{}) string {
+	if v == nil {
+		return msgf("can't decode JSON into nil")
+	}
+	data1, err := json.Marshal(v)
+	if err != nil {
+		return msgf("unexpected marshal error <%v>", err)
+	}
+	data2, err := json.Marshal(v)
+	if err != nil {
+		return msgf("unexpected marshal error <%v>", err)
+	}
+	if !bytes.Equal(data1, data2) {
+		return msgf("JSON differs between marshalings: %v", textDiff(data1, data2))
+	}
+	p := reflect.New(reflect.TypeOf(v))
+	if err = json.Unmarshal(data1, p.Interface()); err != nil {
+		return msgf("unexpected unmarshal error <%v>", err)
+	}
+	data2, err = json.Marshal(p.Elem().Interface())
+	if err != nil {
+		return msgf("unexpected marshal error <%v> after round trip", err)
+	}
+	if !bytes.Equal(data1, data2) {
+		return msgf("JSON differs after round trip: %v", textDiff(data1, data2))
+	}
+	return ""
+}
+
+// AssertStableJSON calls t.Assert(v, HasStableJSON()).
+func (t TB) AssertStableJSON(v interface{}) {
+	t.Helper()
+	t.Assert(v, HasStableJSON())
+}
+
+// textDiffContext is the number of bytes around the first difference printed by textDiff.
+const textDiffContext = 20
+
+// textDiff returns the offset of the first difference between texts x and y,
+// and the part of them around the difference.
+func textDiff(x, y []byte) string {
+	off := 0
+	for off < len(x) && off < len(y) && x[off] == y[off] {
+		off++
+	}
+	snippet := func(b []byte) string {
+		start, end := off-textDiffContext, off+textDiffContext
+		prefix, suffix := "...", "..."
+		if start <= 0 {
+			start, prefix = 0, ""
+		}
+		if end >= len(b) {
+			end, suffix = len(b), ""
+		}
+		return fmt.Sprintf("%q", prefix+string(b[start:end])+suffix)
+	}
+	return msgf("at offset %v, expected <%v> but was <%v>", off, snippet(x), snippet(y))
+}
@@ -0,0 +1,89 @@
+package asserting_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+type Event struct {
+	Name     string
+	At       time.Time
+	Tags     []string
+	internal int
+	Secret   string `json:"-"`
+}
+
+func TestAssertRoundTrip(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	e := Event{Name: "a", At: time.Now(), Tags: []string{"x"}}
+	t.AssertJSONRoundTrip(e)
+	t.AssertGobRoundTrip(e)
+	t.AssertXMLRoundTrip(Record{ID: 1, Name: "a"})
+	t.AssertJSONRoundTrip(&e)
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	e.Secret = "s"
+	e.internal = 1
+	t.AssertJSONRoundTrip(e)
+	t.AssertJSONRoundTrip(func() {})
+	t.AssertRoundTrip(1, json.Marshal, func([]byte, interface{}) error { return errors.New("err") })
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != `round trip mismatch:
+Event.internal: expected <1> but was <0>
+Event.Secret: expected <"s"> but was <"">` ||
+		mock.ErrorMessages[1][0] != "unexpected marshal error <json: unsupported type: func()>" ||
+		mock.ErrorMessages[2][0] != "unexpected unmarshal error <err>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type flaky struct {
+	n *int
+}
+
+func (f flaky) MarshalJSON() ([]byte, error) {
+	*f.n++
+	return json.Marshal(*f.n)
+}
+
+type lossy struct {
+	F float64
+}
+
+func (l *lossy) UnmarshalJSON(data []byte) error {
+	l.F = 0.5
+	return nil
+}
+
+func TestAssertStableJSON(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertStableJSON(map[string]interface{}{"b": 1, "a": []int{1, 2}, "c": 1.5})
+	t.AssertStableJSON(Event{Name: "a", At: time.Now()})
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertStableJSON(flaky{new(int)})
+	t.AssertStableJSON(lossy{1})
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != `JSON differs between marshalings: at offset 0, expected <"1"> but was <"2">` ||
+		mock.ErrorMessages[1][0] != `JSON differs after round trip: at offset 5, expected <"{\"F\":1}"> but was <"{\"F\":0.5}">` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
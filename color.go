@@ -0,0 +1,65 @@
+package asserting
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorReset = "\x1b[0m"
+)
+
+// ColorRenderer returns a Renderer which renders failures with r, and highlights the
+// expected values in green, and the actual values in red, with ANSI escape sequences.
+// In diffs, such as those of EqualsCmp, lines starting with "-" are expected, and
+// lines starting with "+" are actual. Colors are only
+// used if the standard output is a terminal, and none of the environment variables
+// NO_COLOR and CI is set, and TERM is not "dumb". For example:
+//
+//	SetRenderer(ColorRenderer(DefaultRenderer))
+func ColorRenderer(r Renderer) Renderer {
+	return RendererFunc(func(f *Failure) string {
+		s := r.Render(f)
+		if colorEnabled() {
+			s = colorize(s)
+		}
+		return s
+	})
+}
+
+// colorEnabled returns whether failures can be colored, see ColorRenderer.
+func colorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if _, ok := os.LookupEnv("CI"); ok {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+var expectedActual = regexp.MustCompile(`expected (<.*?>) but was (<.*>)`)
+
+// colorize highlights the expected and actual values in s.
+func colorize(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "-"):
+			lines[i] = colorGreen + line + colorReset
+		case strings.HasPrefix(line, "+"):
+			lines[i] = colorRed + line + colorReset
+		default:
+			lines[i] = expectedActual.ReplaceAllString(line,
+				"expected "+colorGreen+"$1"+colorReset+" but was "+colorRed+"$2"+colorReset)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,29 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestColorize(t1 *testing.T) {
+	t := NewTB(t1)
+
+	t.AssertEqual(Colorize("expected <1> but was <<nil>(error)>"), "expected \x1b[32m<1>\x1b[0m but was \x1b[31m<<nil>(error)>\x1b[0m")
+	t.AssertEqual(Colorize("mismatch (-expected +actual):\n  struct{\n- \tA: 1,\n+ \tA: 2,\n  }"),
+		"mismatch (-expected +actual):\n  struct{\n\x1b[32m- \tA: 1,\x1b[0m\n\x1b[31m+ \tA: 2,\x1b[0m\n  }")
+	t.AssertEqual(Colorize("unexpected nil"), "unexpected nil")
+}
+
+func TestColorRendererDisabled(t1 *testing.T) {
+	t1.Setenv("CI", "true")
+	prev := SetRenderer(ColorRenderer(DefaultRenderer))
+	defer SetRenderer(prev)
+
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+	t.AssertEqual(1, 2)
+	if len(mock.ErrorMessages) != 1 || mock.ErrorMessages[0][0] != "expected <2> but was <1>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
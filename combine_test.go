@@ -0,0 +1,42 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/cond"
+)
+
+func TestCombinators(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(5, cond.And(GreaterThan(0), LessThan(10)))
+	t.Assert(5, cond.And())
+	t.Assert(5, cond.Or(Equals(1), Equals(5)))
+	t.Assert(5, cond.Not(Equals(1)))
+	t.Assert(5, cond.Not(cond.Or(LessThan(0), GreaterThan(10))))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(15, cond.And(GreaterThan(0), LessThan(10)))
+	t.Assert(15, cond.And(GreaterThan(0), LessThan(10).SetMessage("too large")))
+	t.Assert(3, cond.Or(Equals(1), Equals(2)))
+	t.Assert(3, cond.Or())
+	t.Assert(1, cond.Not(Equals(1)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != "<15> is not less than <10>" ||
+		mock.ErrorMessages[1][0] != "too large" ||
+		mock.ErrorMessages[2][0] != "none of the conditions is met: expected <1> but was <3>; expected <2> but was <3>" ||
+		mock.ErrorMessages[3][0] != "empty Or is never met" ||
+		mock.ErrorMessages[4][0] != "<1> unexpectedly meets Equals" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+	if cond.Kind(cond.Not(Equals(1))) != "Not(Equals)" || cond.Kind(cond.And()) != "And" {
+		t1.Fatal()
+	}
+}
@@ -0,0 +1,92 @@
+package cond
+
+import (
+	"fmt"
+	"strings"
+)
+
+type and struct {
+	conds  []Cond
+	failed Cond // The false Cond found by the last Test.
+}
+
+// And returns a Cond which is true if all of conds are true, in order, and false at
+// the first false one, whose failure message is the failure message of the Cond.
+// And of no conds is always true.
+// Only the SetFatal of the returned Cond, not that of conds, takes effect.
+func And(conds ...Cond) Cond {
+	return New(&and{conds: conds})
+}
+
+func (c *and) Test(v interface{}) bool {
+	for _, cond := range c.conds {
+		if !cond.Test(v) {
+			c.failed = cond
+			return false
+		}
+	}
+	return true
+}
+
+func (c *and) Message(v interface{}) string {
+	return Message(c.failed, v)
+}
+
+func (c *and) Kind() string {
+	return "And"
+}
+
+type or []Cond
+
+// Or returns a Cond which is true if any of conds is true. The failure message
+// lists the failure messages of all conds.
+// Or of no conds is always false.
+// Only the SetFatal of the returned Cond, not that of conds, takes effect.
+func Or(conds ...Cond) Cond {
+	return New(or(conds))
+}
+
+func (c or) Test(v interface{}) bool {
+	for _, cond := range c {
+		if cond.Test(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c or) Message(v interface{}) string {
+	if len(c) == 0 {
+		return "empty Or is never met"
+	}
+	msgs := make([]string, len(c))
+	for i, cond := range c {
+		msgs[i] = Message(cond, v)
+	}
+	return "none of the conditions is met: " + strings.Join(msgs, "; ")
+}
+
+func (c or) Kind() string {
+	return "Or"
+}
+
+type not struct {
+	c Cond
+}
+
+// Not returns a Cond which is true if c is false.
+func Not(c Cond) Cond {
+	return New(not{c})
+}
+
+func (c not) Test(v interface{}) bool {
+	return !c.c.Test(v)
+}
+
+func (c not) Message(v interface{}) string {
+	return fmt.Sprintf("<%v> unexpectedly meets %v", v, Kind(c.c))
+}
+
+func (c not) Kind() string {
+	return "Not(" + Kind(c.c) + ")"
+}
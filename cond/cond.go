@@ -1,6 +1,11 @@
 // Package cond defines the assertion condition.
 package cond
 
+import (
+	"fmt"
+	"reflect"
+)
+
 // Condition is a condition with failure message.
 type Condition interface {
 	// Test returns whether the condition is met.
@@ -19,6 +24,9 @@ type Cond interface {
 	// SetMessage replaces the default failure message, overwriting function set by
 	// SetMessageFunc if any.
 	SetMessage(msg string) Cond
+	// SetMessagef is like SetMessage, except that the message is formatted with
+	// fmt.Sprintf(format, args...), lazily, only if the message is needed.
+	SetMessagef(format string, args ...interface{}) Cond
 	// SetMessageFunc sets f as the failure message generator, overwriting message set
 	// by SetMessage if any.
 	// If necessary, the failure message will be retrieved lazily from f.
@@ -28,6 +36,8 @@ type Cond interface {
 	SetFatal() Cond
 	fatal() bool
 	message(v interface{}) string
+	check(v interface{}) (ok bool, msg string)
+	condition() Condition
 }
 
 type cond struct {
@@ -41,6 +51,11 @@ func (c *cond) SetMessage(msg string) Cond {
 	return c
 }
 
+func (c *cond) SetMessagef(format string, args ...interface{}) Cond {
+	c.userMsg = func() string { return fmt.Sprintf(format, args...) }
+	return c
+}
+
 func (c *cond) SetMessageFunc(f func() string) Cond {
 	c.userMsg = f
 	return c
@@ -55,6 +70,10 @@ func (c *cond) fatal() bool {
 	return c.isFatal
 }
 
+func (c *cond) condition() Condition {
+	return c.Condition
+}
+
 func (c *cond) message(v interface{}) string {
 	if c.userMsg != nil {
 		return c.userMsg()
@@ -62,6 +81,22 @@ func (c *cond) message(v interface{}) string {
 	return c.Message(v)
 }
 
+func (c *cond) check(v interface{}) (ok bool, msg string) {
+	if checker, ok := c.Condition.(interface{ Check(v interface{}) string }); ok {
+		if msg = checker.Check(v); msg == "" {
+			return true, ""
+		}
+		if c.userMsg != nil {
+			return false, c.userMsg()
+		}
+		return false, msg
+	}
+	if c.Test(v) {
+		return true, ""
+	}
+	return false, c.message(v)
+}
+
 // Fatal returns whether cond.Fatal has been called.
 func Fatal(cond Cond) bool {
 	return cond.fatal()
@@ -74,6 +109,49 @@ func Message(cond Cond, v interface{}) string {
 	return cond.message(v)
 }
 
+// Check tests v with cond, and returns whether v meets cond, and the failure message,
+// see Message, if not. If the Condition cond created with has a method
+//
+//	Check(v interface{}) string
+//
+// which returns the failure message, or an empty string if the condition is met,
+// Check calls that method only, instead of Test and Message, so that the condition
+// is tested once. Calls Test, and Message if Test returns false, otherwise.
+func Check(cond Cond, v interface{}) (ok bool, msg string) {
+	return cond.check(v)
+}
+
+// Kind returns the kind of cond, which describes what the condition tests, e.g. "Equals".
+// If the Condition cond created with has a method
+//
+//	Kind() string
+//
+// Kind returns the result of that method. Returns the type name of the Condition otherwise.
+func Kind(cond Cond) string {
+	c := cond.condition()
+	if k, ok := c.(interface{ Kind() string }); ok {
+		return k.Kind()
+	}
+	t := reflect.TypeOf(c)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// Expected returns the value expected by cond and true, if the Condition cond
+// created with has a method
+//
+//	Expected() interface{}
+//
+// Returns nil and false otherwise.
+func Expected(cond Cond) (expected interface{}, ok bool) {
+	if e, ok := cond.condition().(interface{ Expected() interface{} }); ok {
+		return e.Expected(), true
+	}
+	return nil, false
+}
+
 // New creates a Cond with c.
 func New(c Condition) Cond {
 	return &cond{Condition: c}
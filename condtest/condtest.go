@@ -0,0 +1,89 @@
+// Package condtest verifies that custom implementations of cond.Condition behave
+// as the asserting package expects.
+package condtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// Run verifies c with values which are expected to meet it and values which are
+// expected not to, reporting every violation with t.Error. Run verifies that:
+//
+// Test returns true for every value of passing, and false for every value of failing.
+//
+// Message returns a non-empty message right after Test returns false.
+//
+// c is reusable: results don't change when Test is called repeatedly, in any order.
+//
+// nil is handled: neither Test(nil) nor, if it returns false, Message(nil) panics.
+//
+// Panics of c are recovered and reported.
+func Run(t testing.TB, c cond.Condition, passing, failing []interface{}) {
+	t.Helper()
+	for round := 0; round < 2; round++ {
+		for _, v := range passing {
+			if ok, err := test(c, v); err != nil {
+				t.Error(err)
+			} else if !ok {
+				t.Error(fmt.Sprintf("Test(%v) = false, expected true%v", formatValue(v), roundMsg(round)))
+			}
+		}
+		for _, v := range failing {
+			if ok, err := test(c, v); err != nil {
+				t.Error(err)
+			} else if ok {
+				t.Error(fmt.Sprintf("Test(%v) = true, expected false%v", formatValue(v), roundMsg(round)))
+			} else if msg, err := message(c, v); err != nil {
+				t.Error(err)
+			} else if msg == "" {
+				t.Error(fmt.Sprintf("Message(%v) is empty%v", formatValue(v), roundMsg(round)))
+			}
+		}
+	}
+	if ok, err := test(c, nil); err != nil {
+		t.Error(err)
+	} else if !ok {
+		if _, err := message(c, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// roundMsg describes round, the number of times the values have been tested before.
+func roundMsg(round int) string {
+	if round == 0 {
+		return ""
+	}
+	return " when reused"
+}
+
+// test calls c.Test(v), recovering panics as an error.
+func test(c cond.Condition, v interface{}) (ok bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Test(%v) panicked: %v", formatValue(v), r)
+		}
+	}()
+	return c.Test(v), nil
+}
+
+// message calls c.Message(v), recovering panics as an error.
+func message(c cond.Condition, v interface{}) (msg string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Message(%v) panicked: %v", formatValue(v), r)
+		}
+	}()
+	return c.Message(v), nil
+}
+
+// formatValue formats v with its type.
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("<%[1]v(%[1]T)>", v)
+}
@@ -0,0 +1,68 @@
+package condtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mkch/asserting"
+	"github.com/mkch/asserting/condtest"
+	"github.com/mkch/asserting/mocktb"
+)
+
+// positive is a well behaved condition.
+type positive struct{}
+
+func (positive) Test(v interface{}) bool {
+	i, ok := v.(int)
+	return ok && i > 0
+}
+
+func (positive) Message(v interface{}) string {
+	return fmt.Sprintf("<%v> is not positive", v)
+}
+
+// once meets only the first tested value.
+type once struct {
+	tested bool
+}
+
+func (c *once) Test(v interface{}) bool {
+	tested := c.tested
+	c.tested = true
+	return !tested
+}
+
+func (c *once) Message(v interface{}) string {
+	return ""
+}
+
+// nilPanics panics when testing nil.
+type nilPanics struct{}
+
+func (nilPanics) Test(v interface{}) bool {
+	return v.(int) > 0
+}
+
+func (nilPanics) Message(v interface{}) string {
+	return "failed"
+}
+
+func TestRun(t *testing.T) {
+	r := mocktb.New(t)
+	condtest.Run(r, positive{}, []interface{}{1, 2}, []interface{}{0, -1, "a"})
+	condtest.Run(r, asserting.Equals(1), []interface{}{1}, []interface{}{2, nil})
+	if len(r.Errors()) != 0 {
+		t.Fatal(r.Errors())
+	}
+
+	condtest.Run(r, &once{}, []interface{}{1}, []interface{}{0})
+	condtest.Run(r, nilPanics{}, []interface{}{1}, nil)
+	errors := r.Errors()
+	if len(errors) != 4 ||
+		errors[0] != "Message(<0(int)>) is empty" ||
+		errors[1] != "Test(<1(int)>) = false, expected true when reused" ||
+		errors[2] != "Message(<0(int)>) is empty when reused" ||
+		errors[3] != "Test(<nil>) panicked: interface conversion: interface {} is nil, not int" {
+		t.Fatal(errors)
+	}
+}
@@ -0,0 +1,49 @@
+package asserting
+
+import (
+	"context"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type hasContextValue struct {
+	key interface{}
+	c   cond.Cond
+}
+
+// HasContextValue returns a cond which is true if the tested context.Context carries
+// a non-nil value under key, as returned by its Value method, and the value meets c.
+// A nil c accepts any non-nil value.
+func HasContextValue(key interface{}, c cond.Cond) cond.Cond {
+	return cond.New(&hasContextValue{key: key, c: c})
+}
+
+func (c *hasContextValue) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *hasContextValue) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *hasContextValue) Kind() string {
+	return "HasContextValue"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *hasContextValue) Check(v interface{}) string {
+	ctx, ok := v.(context.Context)
+	if !ok || ctx == nil {
+		return msgf("<%[1]v(%[1]T)> is not a context.Context", v)
+	}
+	value := ctx.Value(c.key)
+	if value == nil {
+		return msgf("context has no value under key <%v(%T)>", formatValue(c.key), c.key)
+	}
+	if c.c != nil {
+		if ok, msg := cond.Check(c.c, value); !ok {
+			return msgf("context value under key <%v(%T)>: %v", formatValue(c.key), c.key, msg)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package asserting_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+type ctxKey string
+
+func TestHasContextValue(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "alice")
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	t.Assert(ctx, HasContextValue(ctxKey("user"), Equals("alice")))
+	t.Assert(ctx, HasContextValue(ctxKey("user"), nil))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(ctx, HasContextValue(ctxKey("user"), Equals("bob")))
+	t.Assert(ctx, HasContextValue("user", nil))
+	t.Assert("ctx", HasContextValue("user", nil))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != `context value under key <"user"(asserting_test.ctxKey)>: expected <"bob"> but was <"alice">` ||
+		mock.ErrorMessages[1][0] != `context has no value under key <"user"(string)>` ||
+		mock.ErrorMessages[2][0] != `<ctx(string)> is not a context.Context` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,370 @@
+package asserting
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// maxDiffs is the max number of differences reported by diff.
+const maxDiffs = 10
+
+// maxDiffDepth is the max depth of nested values walked by diff.
+const maxDiffDepth = 50
+
+// diff returns the differences between expected and actual, one per line,
+// with the path of each differing field, element or key,
+// e.g. "Order.Items[2].Price: expected <100> but was <90>".
+// Values are compared as reflect.DeepEqual does, except that Equal methods
+// are used if available and exact is false, see callEqual.
+// diff returns an empty string if no difference is found.
+func diff(expected, actual interface{}, exact bool) string {
+	x, y := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	var path string
+	if x.IsValid() {
+		path = x.Type().Name()
+	}
+	d := differ{exact: exact}
+	d.walk(path, x, y, 0)
+	if len(d.diffs) > maxDiffs {
+		d.diffs = append(d.diffs[:maxDiffs], "...(more differences)")
+	}
+	return strings.Join(d.diffs, "\n")
+}
+
+// deepEquals returns whether expected and actual are deeply equal,
+// in the sense of diff. Unlike diff, no difference is formatted.
+func deepEquals(expected, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	var d differ
+	return d.equal(reflect.ValueOf(expected), reflect.ValueOf(actual), 0)
+}
+
+type deepEqualsCond struct {
+	expected interface{}
+}
+
+// DeepEquals returns a cond which is true if the tested value and expected are deeply
+// equal, as reflect.DeepEqual reports, except that Equal methods are used if available,
+// see Equals. Values of any type can be compared, including structs, maps, slices and
+// pointers. The failure message lists the path of every difference, e.g.
+// "Order.Items[2].Price: expected <100> but was <90>". Byte slices are compared as
+// Equals does, with bytes.Equal, and their differences are shown as a hexdump.
+func DeepEquals(expected interface{}) cond.Cond {
+	return cond.New(&deepEqualsCond{expected: expected})
+}
+
+func (c *deepEqualsCond) Test(v interface{}) bool {
+	if x, y, ok := byteSlices(c.expected, v); ok {
+		return bytes.Equal(x, y)
+	}
+	return deepEquals(c.expected, v)
+}
+
+func (c *deepEqualsCond) Message(v interface{}) string {
+	if x, y, ok := byteSlices(c.expected, v); ok {
+		return hexdumpDiff(x, y)
+	}
+	x, y := reflect.ValueOf(c.expected), reflect.ValueOf(v)
+	if !x.IsValid() || !y.IsValid() || x.Type() != y.Type() {
+		return formatMsg("expected <%v> but was <%v>", c.expected, v)
+	}
+	switch x.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr:
+		return msgf("values differ:\n%v", diff(c.expected, v, false))
+	default:
+		return formatMsg("expected <%v> but was <%v>", c.expected, v)
+	}
+}
+
+func (c *deepEqualsCond) Kind() string {
+	return "DeepEquals"
+}
+
+func (c *deepEqualsCond) Expected() interface{} {
+	return c.expected
+}
+
+type differ struct {
+	exact        bool
+	funcPointers bool // Whether to compare funcs by their code pointers.
+	diffs        []string
+	visited      map[[2]uintptr]bool // Pointer pairs already walked, to stop at cycles.
+}
+
+func (d *differ) report(path, format string, args ...interface{}) {
+	if path != "" {
+		path += ": "
+	}
+	d.diffs = append(d.diffs, path+msgf(format, args...))
+}
+
+func (d *differ) walk(path string, x, y reflect.Value, depth int) {
+	if len(d.diffs) > maxDiffs {
+		return
+	}
+	if depth > maxDiffDepth {
+		// Too deep to walk, compare the rest as a whole.
+		if !x.CanInterface() || !y.CanInterface() {
+			d.report(path, "too deep to compare")
+		} else if !reflect.DeepEqual(x.Interface(), y.Interface()) {
+			d.report(path, "too deep to show differences, values differ")
+		}
+		return
+	}
+	if !x.IsValid() || !y.IsValid() {
+		if x.IsValid() != y.IsValid() {
+			d.report(path, "expected <%v> but was <%v>", formatLeaf(x), formatLeaf(y))
+		}
+		return
+	}
+	if x.Type() != y.Type() {
+		d.report(path, "expected <%v(%v)> but was <%v(%v)>", formatLeaf(x), x.Type(), formatLeaf(y), y.Type())
+		return
+	}
+	if !d.exact && x.CanInterface() && y.CanInterface() {
+		if eq, ok := callEqual(x.Interface(), y.Interface()); ok {
+			if !eq {
+				d.report(path, "expected <%v> but was <%v>", formatLeaf(x), formatLeaf(y))
+			}
+			return
+		}
+	}
+	switch x.Kind() {
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			d.walk(path+"."+x.Type().Field(i).Name, x.Field(i), y.Field(i), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		if x.Kind() == reflect.Slice {
+			if x.IsNil() != y.IsNil() {
+				d.report(path, "expected <%v> but was <%v>", formatNil(x), formatNil(y))
+				return
+			}
+			if x.Len() != y.Len() {
+				d.report(path, "length mismatch: expected %v but was %v", x.Len(), y.Len())
+				return
+			}
+		}
+		for i := 0; i < x.Len(); i++ {
+			d.walk(fmt.Sprintf("%v[%v]", path, i), x.Index(i), y.Index(i), depth+1)
+		}
+	case reflect.Map:
+		if x.IsNil() != y.IsNil() {
+			d.report(path, "expected <%v> but was <%v>", formatNil(x), formatNil(y))
+			return
+		}
+		keys := x.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return formatLeaf(keys[i]) < formatLeaf(keys[j]) })
+		for _, k := range keys {
+			p := fmt.Sprintf("%v[%v]", path, formatLeaf(k))
+			if v := y.MapIndex(k); !v.IsValid() {
+				d.report(p, "missing")
+			} else {
+				d.walk(p, x.MapIndex(k), v, depth+1)
+			}
+		}
+		keys = y.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return formatLeaf(keys[i]) < formatLeaf(keys[j]) })
+		for _, k := range keys {
+			if !x.MapIndex(k).IsValid() {
+				d.report(fmt.Sprintf("%v[%v]", path, formatLeaf(k)), "unexpected <%v>", formatLeaf(y.MapIndex(k)))
+			}
+		}
+	case reflect.Ptr, reflect.Interface:
+		if x.IsNil() || y.IsNil() {
+			if x.IsNil() != y.IsNil() {
+				d.report(path, "expected <%v> but was <%v>", formatNil(x), formatNil(y))
+			}
+			return
+		}
+		if x.Kind() == reflect.Ptr {
+			k := [2]uintptr{x.Pointer(), y.Pointer()}
+			if d.visited[k] {
+				return
+			}
+			if d.visited == nil {
+				d.visited = make(map[[2]uintptr]bool)
+			}
+			d.visited[k] = true
+		}
+		d.walk(path, x.Elem(), y.Elem(), depth+1)
+	case reflect.Func:
+		if d.funcPointers {
+			if x.Pointer() != y.Pointer() {
+				d.report(path, "expected func <%v> but was <%v>", formatNil(x), formatNil(y))
+			}
+		} else if !x.IsNil() || !y.IsNil() {
+			d.report(path, "funcs are equal only if both are nil")
+		}
+	default:
+		if !leafEquals(x, y) {
+			d.report(path, "expected <%v> but was <%v>", formatLeaf(x), formatLeaf(y))
+		}
+	}
+}
+
+// equal returns whether x and y are equal, i.e. walk reports no difference.
+// equal stops at the first difference, and formats nothing.
+func (d *differ) equal(x, y reflect.Value, depth int) bool {
+	if depth > maxDiffDepth {
+		return x.CanInterface() && y.CanInterface() && reflect.DeepEqual(x.Interface(), y.Interface())
+	}
+	if !x.IsValid() || !y.IsValid() {
+		return x.IsValid() == y.IsValid()
+	}
+	if x.Type() != y.Type() {
+		return false
+	}
+	if !d.exact && x.CanInterface() && y.CanInterface() {
+		if eq, ok := callEqual(x.Interface(), y.Interface()); ok {
+			return eq
+		}
+	}
+	switch x.Kind() {
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			if !d.equal(x.Field(i), y.Field(i), depth+1) {
+				return false
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if x.Kind() == reflect.Slice && (x.IsNil() != y.IsNil() || x.Len() != y.Len()) {
+			return false
+		}
+		for i := 0; i < x.Len(); i++ {
+			if !d.equal(x.Index(i), y.Index(i), depth+1) {
+				return false
+			}
+		}
+	case reflect.Map:
+		if x.IsNil() != y.IsNil() || x.Len() != y.Len() {
+			return false
+		}
+		for iter := x.MapRange(); iter.Next(); {
+			if v := y.MapIndex(iter.Key()); !v.IsValid() || !d.equal(iter.Value(), v, depth+1) {
+				return false
+			}
+		}
+	case reflect.Ptr, reflect.Interface:
+		if x.IsNil() || y.IsNil() {
+			return x.IsNil() == y.IsNil()
+		}
+		if x.Kind() == reflect.Ptr {
+			k := [2]uintptr{x.Pointer(), y.Pointer()}
+			if d.visited[k] {
+				return true
+			}
+			if d.visited == nil {
+				d.visited = make(map[[2]uintptr]bool)
+			}
+			d.visited[k] = true
+		}
+		return d.equal(x.Elem(), y.Elem(), depth+1)
+	case reflect.Func:
+		if d.funcPointers {
+			return x.Pointer() == y.Pointer()
+		}
+		return x.IsNil() && y.IsNil()
+	default:
+		return leafEquals(x, y)
+	}
+	return true
+}
+
+// leafEquals compares 2 values of the same basic kind.
+func leafEquals(x, y reflect.Value) bool {
+	switch x.Kind() {
+	case reflect.Bool:
+		return x.Bool() == y.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return x.Int() == y.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return x.Uint() == y.Uint()
+	case reflect.Float32, reflect.Float64:
+		return x.Float() == y.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return x.Complex() == y.Complex()
+	case reflect.String:
+		return x.String() == y.String()
+	default: // Chan and UnsafePointer.
+		return x.Pointer() == y.Pointer()
+	}
+}
+
+// formatLeaf formats v like formatValue. v may be obtained from unexported fields.
+func formatLeaf(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	if v.CanInterface() {
+		return formatValue(v.Interface())
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// formatNil formats v which may be nil, making nil distinguishable from empty values.
+func formatNil(v reflect.Value) string {
+	if v.IsNil() {
+		return "nil"
+	}
+	return formatLeaf(v)
+}
+
+// hexdumpLines is the number of lines around the first difference printed by hexdumpDiff.
+const hexdumpLines = 1
+
+// hexdumpDiff returns the offset of the first difference between byte slices
+// x and y, followed by a side-by-side hexdump of the 16-byte lines around it.
+func hexdumpDiff(x, y []byte) string {
+	off := 0
+	for off < len(x) && off < len(y) && x[off] == y[off] {
+		off++
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "bytes differ at offset %v (expected length %v but was %v)\n", off, len(x), len(y))
+	fmt.Fprintf(&buf, "%-8v  %-66v  %v", "offset", "expected", "actual")
+	n := len(x)
+	if len(y) > n {
+		n = len(y)
+	}
+	first := off/16 - hexdumpLines
+	if first < 0 {
+		first = 0
+	}
+	for line := first; line <= off/16+hexdumpLines && line*16 < n; line++ {
+		fmt.Fprintf(&buf, "\n%08x  %v  %v", line*16, hexdumpLine(x, line*16), hexdumpLine(y, line*16))
+	}
+	return buf.String()
+}
+
+// hexdumpLine formats the 16 bytes of b starting at off as hex and ASCII.
+func hexdumpLine(b []byte, off int) string {
+	var hex, ascii strings.Builder
+	for i := off; i < off+16; i++ {
+		if i > off {
+			hex.WriteByte(' ')
+		}
+		if i >= len(b) {
+			hex.WriteString("  ")
+			ascii.WriteByte(' ')
+			continue
+		}
+		fmt.Fprintf(&hex, "%02x", b[i])
+		if b[i] >= 0x20 && b[i] <= 0x7e {
+			ascii.WriteByte(b[i])
+		} else {
+			ascii.WriteByte('.')
+		}
+	}
+	return hex.String() + " |" + ascii.String() + "|"
+}
@@ -0,0 +1,141 @@
+package asserting_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+type Item struct {
+	Name  string
+	Price int
+	tags  [2]string
+}
+
+type Order struct {
+	ID    int
+	Items []Item
+	Attrs map[string]interface{}
+	Note  *string
+}
+
+func TestStructDiff(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(Item{"a", 1, [2]string{"x"}}, Equals(Item{"a", 1, [2]string{"x"}}))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(Item{"a", 1, [2]string{"x", "y"}}, Equals(Item{"a ", 2, [2]string{"x", "z"}}))
+	if len(mock.ErrorMessages) != 1 ||
+		mock.ErrorMessages[0][0] != `Item.Name: expected <"a "> but was <"a">
+Item.Price: expected <2> but was <1>
+Item.tags[1]: expected <"z"> but was <"y">` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	note1, note2 := "a", "b"
+	order1 := Order{1, []Item{{Name: "a"}, {Name: "b", Price: 100}}, map[string]interface{}{"a": 1, "b": 2}, &note1}
+	order2 := Order{1, []Item{{Name: "a"}, {Name: "b", Price: 90}}, map[string]interface{}{"a": int64(1), "c": 3}, &note2}
+	if d := Diff(order1, order2); d != `Order.Items[1].Price: expected <100> but was <90>
+Order.Attrs["a"]: expected <1(int)> but was <1(int64)>
+Order.Attrs["b"]: missing
+Order.Attrs["c"]: unexpected <3>
+Order.Note: expected <"a"> but was <"b">` {
+		t1.Fatal(d)
+	}
+
+	order2 = Order{2, nil, nil, nil}
+	if d := Diff(order1, order2); d != `Order.ID: expected <1> but was <2>
+Order.Items: expected <[{a 0 [ ]} {b 100 [ ]}]> but was <nil>
+Order.Attrs: expected <map[a:1 b:2]> but was <nil>
+Order.Note: expected <`+fmt.Sprint(&note1)+`> but was <nil>` {
+		t1.Fatal(d)
+	}
+
+	if d := Diff(order1, order1); d != "" {
+		t1.Fatal(d)
+	}
+}
+
+func TestHexdumpDiff(t1 *testing.T) {
+	x := []byte("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	y := append([]byte(nil), x...)
+	y[40] = 0
+	if d := HexdumpDiff(x, y); d != "bytes differ at offset 40 (expected length 64 but was 64)\n"+
+		"offset    expected                                                            actual\n"+
+		"00000010  30 31 32 33 34 35 36 37 38 39 61 62 63 64 65 66 |0123456789abcdef|  30 31 32 33 34 35 36 37 38 39 61 62 63 64 65 66 |0123456789abcdef|\n"+
+		"00000020  30 31 32 33 34 35 36 37 38 39 61 62 63 64 65 66 |0123456789abcdef|  30 31 32 33 34 35 36 37 00 39 61 62 63 64 65 66 |01234567.9abcdef|\n"+
+		"00000030  30 31 32 33 34 35 36 37 38 39 61 62 63 64 65 66 |0123456789abcdef|  30 31 32 33 34 35 36 37 38 39 61 62 63 64 65 66 |0123456789abcdef|" {
+		t1.Fatal(d)
+	}
+}
+
+func TestDeepEquals(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	one, another := 1, 1
+	t.Assert(map[string][]int{"a": {1}}, DeepEquals(map[string][]int{"a": {1}}))
+	t.Assert(&one, DeepEquals(&another))
+	t.Assert([]*int{&one}, DeepEquals([]*int{&one}))
+	t.Assert(Order{Items: []Item{{Price: 100}}}, DeepEquals(Order{Items: []Item{{Price: 100}}}))
+	t.Assert(1, DeepEquals(1))
+	t.Assert(nil, DeepEquals(nil))
+	t.Assert([]byte{}, DeepEquals([]byte(nil)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(map[string][]int{"a": {1}, "b": nil}, DeepEquals(map[string][]int{"a": {2}}))
+	t.Assert(1, DeepEquals(int64(1)))
+	t.Assert("a", DeepEquals("b"))
+	t.Assert(map[int]int{}, Equals(map[int]int{}))
+	t.Assert([]byte("hello"), DeepEquals([]byte("help")))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != "values differ:\n[\"a\"][0]: expected <2> but was <1>\n[\"b\"]: unexpected <[]>" ||
+		mock.ErrorMessages[1][0] != "expected <1(int64)> but was <1(int)>" ||
+		mock.ErrorMessages[2][0] != `expected <"b"> but was <"a">` ||
+		mock.ErrorMessages[3][0] != "type map[int]int is not comparable; use DeepEquals" ||
+		mock.ErrorMessages[4][0] != HexdumpDiff([]byte("help"), []byte("hello")) {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type chain struct {
+	V    int
+	Next *chain
+}
+
+// newChain returns a chain of n nodes, with V of the last node being tail.
+func newChain(n, tail int) *chain {
+	c := &chain{V: tail}
+	for i := 1; i < n; i++ {
+		c = &chain{Next: c}
+	}
+	return c
+}
+
+func TestDeepEqualsTooDeep(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(newChain(60, 1), DeepEquals(newChain(60, 1)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(newChain(60, 1), DeepEquals(newChain(60, 2)))
+	t.Assert([]*chain{newChain(60, 1)}, ElementsMatch([]*chain{newChain(60, 2)}))
+	if len(mock.ErrorMessages) != 2 ||
+		!strings.HasSuffix(mock.ErrorMessages[0][0].(string), ".Next: too deep to show differences, values differ") {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
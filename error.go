@@ -0,0 +1,104 @@
+package asserting
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type errorAs struct {
+	target interface{} // A non-nil pointer, as the target of errors.As.
+	conds  []cond.Cond
+}
+
+// ErrorAs returns a cond which is true if the tested error has an error in its chain
+// which can be assigned to the value pointed to by target, as errors.As reports.
+// When true, the error found is stored in *target, so that it can be inspected
+// further. If any cond is provided, the error found must also meet all of them.
+// ErrorAs panics if target is not a non-nil pointer to either a type implementing
+// error, or any interface type.
+func ErrorAs(target interface{}, conds ...cond.Cond) cond.Cond {
+	checkErrorTarget(target)
+	return cond.New(&errorAs{target: target, conds: conds})
+}
+
+// ErrorAsT returns a cond which is like ErrorAs, with a target of type T.
+// The error found is available only to conds.
+func ErrorAsT[T any](conds ...cond.Cond) cond.Cond {
+	return ErrorAs(new(T), conds...)
+}
+
+// checkErrorTarget panics if target is not a valid target of errors.As.
+func checkErrorTarget(target interface{}) {
+	t := reflect.TypeOf(target)
+	if t == nil || t.Kind() != reflect.Ptr || reflect.ValueOf(target).IsNil() ||
+		(t.Elem().Kind() != reflect.Interface && !t.Elem().Implements(errorType)) {
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a non-nil pointer to an error or interface type", target))
+	}
+}
+
+func (c *errorAs) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *errorAs) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *errorAs) Kind() string {
+	return "ErrorAs"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *errorAs) Check(v interface{}) string {
+	t := reflect.TypeOf(c.target).Elem()
+	err, ok := v.(error)
+	if v != nil && !ok {
+		return msgf("<%[1]v(%[1]T)> is not an error", v)
+	}
+	if !errors.As(err, c.target) {
+		return msgf("no error in the chain of <%v(%T)> is <%v>", formatValue(v), v, t)
+	}
+	found := reflect.ValueOf(c.target).Elem().Interface()
+	for _, ec := range c.conds {
+		if ok, msg := cond.Check(ec, found); !ok {
+			return msgf("error <%v> in the chain: %v", t, msg)
+		}
+	}
+	return ""
+}
+
+type isError struct{}
+
+// IsError returns a cond which is true if a value is a non-nil error. As with
+// TB.AssertNoError, an error interface holding a nil pointer is regarded as nil.
+func IsError() cond.Cond {
+	return cond.New(isError{})
+}
+
+func (c isError) Test(v interface{}) bool {
+	_, ok := v.(error)
+	return ok && !equalsNil(v)
+}
+
+func (c isError) Message(v interface{}) string {
+	if v == nil {
+		return msgf("expected error but was nil")
+	}
+	if equalsNil(v) {
+		return msgf("expected error but was typed nil <%T>", v)
+	}
+	return msgf("<%[1]v(%[1]T)> is not an error", v)
+}
+
+func (c isError) Kind() string {
+	return "IsError"
+}
+
+// AssertError asserts err is not nil.
+func (t TB) AssertError(err error) {
+	t.Helper()
+	t.Assert(err, IsError())
+}
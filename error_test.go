@@ -0,0 +1,82 @@
+package asserting_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+type temporary interface {
+	Temporary() bool
+}
+
+type tempError struct{}
+
+func (tempError) Error() string   { return "temp" }
+func (tempError) Temporary() bool { return true }
+
+func TestErrorAs(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	_, err := os.Open("/does/not/exist")
+	err = fmt.Errorf("loading: %w", err)
+	var pathErr *fs.PathError
+	t.Assert(err, ErrorAs(&pathErr))
+	t.AssertEqual(pathErr.Path, "/does/not/exist")
+	t.Assert(err, ErrorAsT[*fs.PathError](HasField("Op", Equals("open"))))
+	t.Assert(fmt.Errorf("%w", tempError{}), ErrorAsT[temporary]())
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(errors.New("plain"), ErrorAsT[*fs.PathError]())
+	t.Assert(nil, ErrorAsT[*fs.PathError]())
+	t.Assert(err, ErrorAsT[*fs.PathError](HasField("Op", Equals("read"))))
+	t.Assert("err", ErrorAsT[*fs.PathError]())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "no error in the chain of <plain(*errors.errorString)> is <*fs.PathError>" ||
+		mock.ErrorMessages[1][0] != "no error in the chain of <<nil>(<nil>)> is <*fs.PathError>" ||
+		mock.ErrorMessages[2][0] != `error <*fs.PathError> in the chain: field Op of <fs.PathError>: expected <"read"> but was <"open">` ||
+		mock.ErrorMessages[3][0] != `<err(string)> is not an error` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(func() { ErrorAs(pathErr) }, Panics("<open /does/not/exist: no such file or directory(*fs.PathError)> is not a non-nil pointer to an error or interface type"))
+	t.Assert(func() { ErrorAsT[int]() }, PanicMatches(func(interface{}) bool { return true }))
+	if len(mock.ErrorMessages) != 4 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestAssertError(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertError(errors.New("err"))
+	t.Assert(tempError{}, IsError())
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	var nilErr *nilError
+	t.AssertError(nil)
+	t.AssertError(nilErr)
+	t.Assert("err", IsError())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "expected error but was nil" ||
+		mock.ErrorMessages[1][0] != "expected error but was typed nil <*asserting_test.nilError>" ||
+		mock.ErrorMessages[2][0] != "<err(string)> is not an error" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
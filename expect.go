@@ -0,0 +1,113 @@
+package asserting
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mkch/asserting/cond"
+	"github.com/mkch/asserting/mocktb"
+)
+
+// ExpectFailure calls f with a TB recording failures instead of reporting them, and
+// asserts that f reported at least one failure, with Error, Fatal or their variants.
+// Each of msgConds, if any, must be met by at least one of the failure messages.
+// ExpectFailure is useful to test conditions and helpers built on top of TB.
+// f is called in a goroutine of its own, which is stopped by FailNow, SkipNow, and
+// their variants, like the test goroutine of testing.T.
+// The inner TB has a name of its own, and its assertions are not recorded in the
+// stats, the hooks and the outputs, such as the TAP, of the test of t.
+func (t TB) ExpectFailure(f func(inner TB), msgConds ...cond.Cond) {
+	t.Helper()
+	m := mocktb.New(t.TB)
+	inner := &expectTB{TB: m, name: fmt.Sprintf("%v/ExpectFailure#%v", t.Name(), expectSeq.Add(1))}
+	silentTests.Store(inner.name, true)
+	defer silentTests.Delete(inner.name)
+	done := make(chan struct{})
+	var panicked interface{}
+	go func() {
+		defer close(done)
+		defer func() { panicked = recover() }()
+		f(NewTB(inner))
+	}()
+	<-done
+	if panicked != nil {
+		panic(panicked)
+	}
+	msgs := append(m.Errors(), m.Fatals()...)
+	if !m.Failed() {
+		t.Error("expected failure but none was reported")
+		return
+	}
+	for _, c := range msgConds {
+		if !anyMeets(msgs, c) {
+			t.Error(msgf("no failure message meets %v: %q", cond.Kind(c), msgs))
+		}
+	}
+}
+
+// anyMeets returns whether any of msgs meets c.
+func anyMeets(msgs []string, c cond.Cond) bool {
+	for _, msg := range msgs {
+		if c.Test(msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// expectTB is the inner TB of ExpectFailure.
+type expectTB struct {
+	*mocktb.TB
+	name string
+}
+
+func (e *expectTB) Name() string {
+	return e.name
+}
+
+func (e *expectTB) Fatal(args ...interface{}) {
+	e.TB.Fatal(args...)
+	runtime.Goexit()
+}
+
+func (e *expectTB) Fatalf(format string, args ...interface{}) {
+	e.TB.Fatalf(format, args...)
+	runtime.Goexit()
+}
+
+func (e *expectTB) FailNow() {
+	e.TB.FailNow()
+	runtime.Goexit()
+}
+
+func (e *expectTB) Skip(args ...interface{}) {
+	e.TB.Skip(args...)
+	runtime.Goexit()
+}
+
+func (e *expectTB) Skipf(format string, args ...interface{}) {
+	e.TB.Skipf(format, args...)
+	runtime.Goexit()
+}
+
+func (e *expectTB) SkipNow() {
+	e.TB.SkipNow()
+	runtime.Goexit()
+}
+
+var _ testing.TB = (*expectTB)(nil)
+
+// expectSeq is the sequence number of the inner TBs of ExpectFailure.
+var expectSeq atomic.Int64
+
+// silentTests is the set of names of the tests whose assertions are not recorded.
+var silentTests sync.Map
+
+// silent returns whether the assertions of test are not recorded.
+func silent(test string) bool {
+	_, ok := silentTests.Load(test)
+	return ok
+}
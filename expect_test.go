@@ -0,0 +1,79 @@
+package asserting_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestExpectFailure(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	hasPrefix := func(prefix string) func(v interface{}) bool {
+		return func(v interface{}) bool { return strings.HasPrefix(v.(string), prefix) }
+	}
+	t.ExpectFailure(func(t TB) { t.AssertEqual(1, 2) })
+	t.ExpectFailure(func(t TB) { t.Fatal("boom") }, Equals("boom"))
+	t.ExpectFailure(func(t TB) {
+		t.AssertEqual(1, 2)
+		t.AssertEqual("a", "b")
+	}, Equals("expected <2> but was <1>"), Matches(hasPrefix(`expected <"b">`)))
+	t.ExpectFailure(func(t TB) { t.Fail() })
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.ExpectFailure(func(t TB) { t.AssertEqual(1, 1) })
+	t.ExpectFailure(func(t TB) { t.AssertEqual(1, 2) }, Equals("boom"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected failure but none was reported" ||
+		mock.ErrorMessages[1][0] != `no failure message meets Equals: ["expected <2> but was <1>"]` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestExpectFailureIsolated(t1 *testing.T) {
+	var hooks int
+	t1.Run("", func(t2 *testing.T) {
+		t := NewTB(mocktb.New(t2))
+		t.OnFailure(func(info *FailureInfo) { hooks++ })
+		t.AssertEqual(1, 1)
+		var name string
+		t.ExpectFailure(func(inner TB) {
+			name = inner.Name()
+			inner.AssertEqual(1, 2)
+		})
+		if name == t.Name() || !strings.HasPrefix(name, t.Name()+"/") {
+			t1.Error(name)
+		}
+		if stats := t.Stats(); stats.Assertions != 1 || stats.Failures != 0 {
+			t1.Error(stats)
+		}
+	})
+	if hooks != 0 {
+		t1.Fatal(hooks)
+	}
+}
+
+func TestExpectFailureStopsAtFatal(t1 *testing.T) {
+	t := NewTB(t1)
+	var reached bool
+	t.ExpectFailure(func(t TB) {
+		t.Require().AssertEqual(1, 2)
+		reached = true
+	}, Equals("expected <2> but was <1>"))
+	t.ExpectFailure(func(t TB) {
+		t.FailNow()
+		reached = true
+	})
+	if reached {
+		t1.Fatal("f is not stopped by FailNow")
+	}
+	t.AssertPanic(func() { t.ExpectFailure(func(t TB) { panic("boom") }) }, "boom")
+}
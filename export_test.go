@@ -0,0 +1,19 @@
+package asserting
+
+import "reflect"
+
+func Diff(expected, actual interface{}) string {
+	return diff(expected, actual, false)
+}
+
+var HexdumpDiff = hexdumpDiff
+var Colorize = colorize
+var RunJUnitFunc = runJUnit
+
+var GoWait = &goWait
+
+func UnregisterFormatter[T any]() {
+	formatters.Delete(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+const MaxRegexps = maxRegexps
@@ -0,0 +1,124 @@
+package asserting
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type field struct {
+	path string
+	c    cond.Cond
+}
+
+// Field returns a cond which is true if the value at path of the tested value meets c.
+// path is a dot separated list of names, e.g. "User.Address.City". Each name selects
+// an exported field of a struct, including promoted fields, or the value of a map with
+// string keys. Pointers and interfaces are followed.
+func Field(path string, c cond.Cond) cond.Cond {
+	return cond.New(&field{path: path, c: c})
+}
+
+func (c *field) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *field) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *field) Kind() string {
+	return "Field"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *field) Check(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	names := strings.Split(c.path, ".")
+	for i, name := range names {
+		prefix := strings.Join(names[:i], ".")
+		for (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		switch {
+		case rv.Kind() == reflect.Struct:
+			f, ok := rv.Type().FieldByName(name)
+			if !ok || f.PkgPath != "" {
+				return msgf("%v: type <%v> has no exported field %v", pathOf(v, prefix), rv.Type(), name)
+			}
+			fv, err := rv.FieldByIndexErr(f.Index)
+			if err != nil { // Through a nil embedded pointer.
+				return msgf("%v: %v", pathOf(v, prefix+"."+name), err)
+			}
+			rv = fv
+		case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+			mv := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+			if !mv.IsValid() {
+				return msgf("%v: map has no key %q", pathOf(v, prefix), name)
+			}
+			rv = mv
+		default:
+			return msgf("%v: <%v> is neither a struct nor a map with string keys", pathOf(v, prefix), formatLeaf(rv))
+		}
+	}
+	fv := rv.Interface()
+	if ok, msg := cond.Check(c.c, fv); !ok {
+		return msgf("%v: %v", pathOf(v, c.path), msg)
+	}
+	return ""
+}
+
+// pathOf returns path in v, prefixed with the type of v, or the type pointed to
+// by v if v is a pointer.
+func pathOf(v interface{}, path string) string {
+	root := strings.TrimPrefix(fmt.Sprintf("%T", v), "*")
+	if path == "" {
+		return root
+	}
+	return root + "." + strings.TrimPrefix(path, ".")
+}
+
+type key struct {
+	key interface{}
+	c   cond.Cond
+}
+
+// Key returns a cond which is true if the tested map has the key, and the value of
+// the key meets c.
+func Key(k interface{}, c cond.Cond) cond.Cond {
+	return cond.New(&key{key: k, c: c})
+}
+
+func (c *key) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *key) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *key) Kind() string {
+	return "Key"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *key) Check(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return msgf("<%[1]v(%[1]T)> is not a map", v)
+	}
+	k := reflect.ValueOf(c.key)
+	if !k.IsValid() || !k.Type().AssignableTo(rv.Type().Key()) {
+		return msgf("key <%v> is not assignable to <%v>", formatValue(c.key), rv.Type().Key())
+	}
+	mv := rv.MapIndex(k)
+	if !mv.IsValid() {
+		return msgf("map has no key <%v>", formatValue(c.key))
+	}
+	if ok, msg := cond.Check(c.c, mv.Interface()); !ok {
+		return msgf("key <%v>: %v", formatValue(c.key), msg)
+	}
+	return ""
+}
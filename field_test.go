@@ -0,0 +1,76 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+type address struct {
+	City string
+}
+
+type person struct {
+	Name    string
+	Address *address
+	Tags    map[string]interface{}
+	age     int
+}
+
+func TestField(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	p := &person{Name: "a", Address: &address{City: "x"}, Tags: map[string]interface{}{"team": address{"y"}}}
+	t.Assert(p, Field("Name", Equals("a")))
+	t.Assert(*p, Field("Address.City", Equals("x")))
+	t.Assert(p, Field("Tags.team.City", Equals("y")))
+	t.Assert(map[string]*person{"p": p}, Field("p.Name", Equals("a")))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(p, Field("Address.City", Equals("z")))
+	t.Assert(p, Field("age", Equals(0)))
+	t.Assert(p, Field("Tags.owner", Equals(0)))
+	t.Assert(&person{}, Field("Address.City", Equals("")))
+	t.Assert(1, Field("A", Equals(0)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != `asserting_test.person.Address.City: expected <"z"> but was <"x">` ||
+		mock.ErrorMessages[1][0] != "asserting_test.person: type <asserting_test.person> has no exported field age" ||
+		mock.ErrorMessages[2][0] != `asserting_test.person.Tags: map has no key "owner"` ||
+		mock.ErrorMessages[3][0] != "asserting_test.person.Address: <<nil>> is neither a struct nor a map with string keys" ||
+		mock.ErrorMessages[4][0] != "int: <1> is neither a struct nor a map with string keys" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestKey(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	m := map[string]int{"id": 1}
+	t.Assert(m, Key("id", Equals(1)))
+	t.Assert(map[int]string{1: "a"}, Key(1, Equals("a")))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(m, Key("id", Equals(2)))
+	t.Assert(m, Key("name", Equals(1)))
+	t.Assert(m, Key(1, Equals(1)))
+	t.Assert([]int{1}, Key(0, Equals(1)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != `key <"id">: expected <2> but was <1>` ||
+		mock.ErrorMessages[1][0] != `map has no key <"name">` ||
+		mock.ErrorMessages[2][0] != "key <1> is not assignable to <string>" ||
+		mock.ErrorMessages[3][0] != "<[1]([]int)> is not a map" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,220 @@
+package asserting
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// statPath returns the absolute path and the file info of the tested path v.
+// msg is not empty if v is not a string, or the file can't be stat'ed. notExist is
+// true if the file doesn't exist.
+func statPath(v interface{}) (abs string, info fs.FileInfo, notExist bool, msg string) {
+	path, ok := v.(string)
+	if !ok {
+		return "", nil, false, msgf("<%[1]v(%[1]T)> is not a path string", v)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	info, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return abs, nil, true, msgf("%v does not exist", abs)
+	}
+	if err != nil {
+		return abs, nil, false, msgf("%v: %v", abs, err)
+	}
+	return abs, info, false, ""
+}
+
+type fileExists struct {
+	dir bool // Whether a directory is expected, instead of a regular file.
+}
+
+// FileExists returns a cond which is true if the tested path, a string, names an
+// existing regular file, following symbolic links. Failure messages include the
+// absolute path.
+func FileExists() cond.Cond {
+	return cond.New(&fileExists{})
+}
+
+// DirExists returns a cond which is true if the tested path, a string, names an
+// existing directory, following symbolic links. Failure messages include the
+// absolute path.
+func DirExists() cond.Cond {
+	return cond.New(&fileExists{dir: true})
+}
+
+func (c *fileExists) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *fileExists) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *fileExists) Kind() string {
+	if c.dir {
+		return "DirExists"
+	}
+	return "FileExists"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *fileExists) Check(v interface{}) string {
+	abs, info, _, msg := statPath(v)
+	switch {
+	case msg != "":
+		return msg
+	case c.dir && !info.IsDir():
+		return msgf("%v is not a directory", abs)
+	case !c.dir && info.IsDir():
+		return msgf("%v is a directory, not a regular file", abs)
+	case !c.dir && !info.Mode().IsRegular():
+		return msgf("%v is not a regular file", abs)
+	default:
+		return ""
+	}
+}
+
+type notExists struct{}
+
+// NotExists returns a cond which is true if the tested path, a string, names no file.
+func NotExists() cond.Cond {
+	return cond.New(notExists{})
+}
+
+func (notExists) Test(v interface{}) bool {
+	_, _, notExist, _ := statPath(v)
+	return notExist
+}
+
+func (notExists) Message(v interface{}) string {
+	abs, _, notExist, msg := statPath(v)
+	if msg != "" && !notExist {
+		return msg
+	}
+	return msgf("%v exists", abs)
+}
+
+func (notExists) Kind() string {
+	return "NotExists"
+}
+
+type hasPerm struct {
+	perm fs.FileMode
+}
+
+// HasPerm returns a cond which is true if the tested path, a string, names an existing
+// file, whose Unix permission bits are perm, e.g. 0o644.
+func HasPerm(perm fs.FileMode) cond.Cond {
+	return cond.New(&hasPerm{perm: perm.Perm()})
+}
+
+func (c *hasPerm) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *hasPerm) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *hasPerm) Kind() string {
+	return "HasPerm"
+}
+
+func (c *hasPerm) Expected() interface{} {
+	return c.perm
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *hasPerm) Check(v interface{}) string {
+	abs, info, _, msg := statPath(v)
+	if msg != "" {
+		return msg
+	}
+	if perm := info.Mode().Perm(); perm != c.perm {
+		return msgf("%v: expected permissions <%v> but was <%v>", abs, c.perm, perm)
+	}
+	return ""
+}
+
+type fileContent struct {
+	c cond.Cond
+}
+
+// FileContent returns a cond which is true if the tested path, a string, names an
+// existing regular file, whose content, read as a string, meets c, e.g.
+// FileContent(Equals("exact content")).
+func FileContent(c cond.Cond) cond.Cond {
+	return cond.New(&fileContent{c: c})
+}
+
+// FileContains returns a cond which is true if the tested path, a string, names an
+// existing regular file, whose content contains substr.
+func FileContains(substr string) cond.Cond {
+	return FileContent(cond.New(&contains{substr: substr}))
+}
+
+func (c *fileContent) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *fileContent) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *fileContent) Kind() string {
+	return "FileContent"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *fileContent) Check(v interface{}) string {
+	if msg := (&fileExists{}).Check(v); msg != "" {
+		return msg
+	}
+	abs, _ := filepath.Abs(v.(string))
+	b, err := os.ReadFile(v.(string))
+	if err != nil {
+		return msgf("%v: %v", abs, err)
+	}
+	if ok, msg := cond.Check(c.c, string(b)); !ok {
+		return msgf("%v: %v", abs, msg)
+	}
+	return ""
+}
+
+type contains struct {
+	substr string
+}
+
+func (c *contains) Test(v interface{}) bool {
+	return strings.Contains(v.(string), c.substr)
+}
+
+func (c *contains) Message(v interface{}) string {
+	return msgf("content does not contain <%v>", c.substr)
+}
+
+// AssertFileExists calls t.Assert(path, FileExists()).
+func (t TB) AssertFileExists(path string) {
+	t.Helper()
+	t.Assert(path, FileExists())
+}
+
+// AssertDirExists calls t.Assert(path, DirExists()).
+func (t TB) AssertDirExists(path string) {
+	t.Helper()
+	t.Assert(path, DirExists())
+}
+
+// AssertFileContains calls t.Assert(path, FileContains(substr)).
+func (t TB) AssertFileContains(path, substr string) {
+	t.Helper()
+	t.Assert(path, FileContains(substr))
+}
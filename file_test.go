@@ -0,0 +1,77 @@
+package asserting_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestFileConds(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	dir := t1.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(file, []byte("hello, file\n"), 0o600); err != nil {
+		t1.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	t.AssertFileExists(file)
+	t.AssertDirExists(dir)
+	t.AssertFileContains(file, "file")
+	t.Assert(file, FileContent(Equals("hello, file\n")))
+	t.Assert(missing, NotExists())
+	if runtime.GOOS != "windows" {
+		t.Assert(file, HasPerm(0o600))
+	}
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertFileExists(dir)
+	t.AssertDirExists(file)
+	t.AssertFileExists(missing)
+	t.AssertFileContains(file, "world")
+	t.Assert(file, FileContent(Equals("")))
+	t.Assert(file, NotExists())
+	t.Assert(1, FileExists())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 7 ||
+		mock.ErrorMessages[0][0] != dir+" is a directory, not a regular file" ||
+		mock.ErrorMessages[1][0] != file+" is not a directory" ||
+		mock.ErrorMessages[2][0] != missing+" does not exist" ||
+		mock.ErrorMessages[3][0] != file+": content does not contain <world>" ||
+		mock.ErrorMessages[4][0] != file+`: expected <""> but was <"hello, file\n">` ||
+		mock.ErrorMessages[5][0] != file+" exists" ||
+		mock.ErrorMessages[6][0] != "<1(int)> is not a path string" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	if runtime.GOOS != "windows" {
+		mock.ErrorMessages = nil
+		t.Assert(file, HasPerm(0o644))
+		if len(mock.ErrorMessages) != 1 || mock.ErrorMessages[0][0] != file+": expected permissions <-rw-r--r--> but was <-rw------->" {
+			t1.Fatal(mock.ErrorMessages)
+		}
+	}
+}
+
+func TestFileCondsRelative(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	abs, err := filepath.Abs("testdata/missing")
+	if err != nil {
+		t1.Fatal(err)
+	}
+	t.AssertFileExists("testdata/missing")
+	if len(mock.ErrorMessages) != 1 || mock.ErrorMessages[0][0] != abs+" does not exist" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
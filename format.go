@@ -0,0 +1,31 @@
+package asserting
+
+import (
+	"reflect"
+	"sync"
+)
+
+// formatters maps reflect.Type to func(interface{}) string.
+var formatters sync.Map
+
+// RegisterFormatter registers f to format values of type T in failure messages
+// of all conditions, replacing the formatter previously registered for T if any.
+// T should be a concrete type, values are matched by their exact dynamic type.
+// RegisterFormatter is safe for concurrent use, but is typically called in an init
+// function or TestMain.
+func RegisterFormatter[T any](f func(T) string) {
+	formatters.Store(reflect.TypeOf((*T)(nil)).Elem(), func(v interface{}) string { return f(v.(T)) })
+}
+
+// customFormat formats v with the formatter registered for its type.
+// ok is false if there is no such formatter.
+func customFormat(v interface{}) (str string, ok bool) {
+	if v == nil {
+		return
+	}
+	f, ok := formatters.Load(reflect.TypeOf(v))
+	if !ok {
+		return
+	}
+	return f.(func(interface{}) string)(v), true
+}
@@ -0,0 +1,41 @@
+package asserting_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+type Cents int64
+
+type Price struct {
+	Amount   Cents
+	Currency string
+}
+
+func init() {
+	RegisterFormatter(func(c Cents) string {
+		return fmt.Sprintf("%d.%02d", c/100, c%100)
+	})
+}
+
+func TestRegisterFormatter(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(Cents(1234), Equals(Cents(1200)))
+	t.Assert(Cents(1234), NotEquals(Cents(1234)))
+	t.Assert(Price{1234, "USD"}, Equals(Price{1200, "USD"}))
+	t.Assert([]interface{}{Cents(1)}, EqualsSlice([]interface{}{1}))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "expected <12.00> but was <12.34>" ||
+		mock.ErrorMessages[1][0] != "unexpected <12.34>" ||
+		mock.ErrorMessages[2][0] != "Price.Amount: expected <12.00> but was <12.34>" ||
+		mock.ErrorMessages[3][0] != "expected <[1(int)]> but was <[0.01(asserting_test.Cents)]>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
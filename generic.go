@@ -0,0 +1,114 @@
+package asserting
+
+import (
+	"reflect"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type equalsT[T comparable] struct {
+	equals
+}
+
+// EqualsT returns a cond which is like Equals, except that the tested value must be
+// of type T, the type of expected. Values of other types, e.g. int64 when T is int,
+// are reported as type mismatches. Use AssertT to check the type at compile time.
+func EqualsT[T comparable](expected T) cond.Cond {
+	return cond.New(&equalsT[T]{equals{expected: expected}})
+}
+
+func (c *equalsT[T]) Test(v interface{}) bool {
+	return isT[T](v) && c.equals.Test(v)
+}
+
+func (c *equalsT[T]) Message(v interface{}) string {
+	if !isT[T](v) {
+		return msgf("expected type <%v> but was <%v(%T)>", typeName[T](), formatValue(v), v)
+	}
+	return c.equals.Message(v)
+}
+
+func (c *equalsT[T]) Kind() string {
+	return "EqualsT"
+}
+
+// isT returns whether v holds a value of type T. A nil interface{} holds the nil value
+// of interface type T.
+func isT[T any](v interface{}) bool {
+	if v == nil {
+		return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Interface
+	}
+	_, ok := v.(T)
+	return ok
+}
+
+// typeName returns the name of type T, which may be an interface type.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// AssertT asserts got equals to want, as EqualsT tests. Unlike TB.AssertEqual,
+// got and want must be of the same type T, which is checked at compile time.
+func AssertT[T comparable](t TB, got, want T) {
+	t.Helper()
+	t.Assert(got, EqualsT(want))
+}
+
+// Must returns v if err is nil, otherwise stops the test with Fatal, reporting err.
+// Must makes setup code shorter:
+//
+//	f, err := os.Open(path)
+//	f = Must(t, f, err)
+//
+// See MustValue for calling a function returning (T, error) directly.
+func Must[T any](t TB, v T, err error) T {
+	t.Helper()
+	t.Assert(err, Equals(nil).SetMessage(msgf("unexpected error <%v>", err)).SetFatal())
+	return v
+}
+
+// MustValue returns a function which calls Must(t, v, err). Go does not allow passing
+// the results of a function call along with other arguments, so Must(t, os.Open(path))
+// does not compile, while
+//
+//	f := MustValue(os.Open(path))(t)
+//
+// does.
+func MustValue[T any](v T, err error) func(t TB) T {
+	return func(t TB) T {
+		t.Helper()
+		return Must(t, v, err)
+	}
+}
+
+// CondT is a cond.Cond testing values of type T. The type parameter lets functions
+// such as AssertOK check the type of the tested value at compile time.
+type CondT[T any] struct {
+	cond.Cond
+}
+
+// Typed returns c as a CondT testing values of type T, e.g. Typed[int](Equals(42)).
+func Typed[T any](c cond.Cond) CondT[T] {
+	return CondT[T]{c}
+}
+
+// AssertOK asserts err is nil, and v meets c, in one call. c is not tested if err is
+// not nil. Unlike t.Assert(ValueError(v, err), c), v and c must be of the same type T,
+// which is checked at compile time, e.g.
+//
+//	n, err := strconv.Atoi(s)
+//	AssertOK(t, n, err, Typed[int](Equals(42)))
+//
+// does not compile if s is parsed with strconv.ParseInt, which returns an int64.
+func AssertOK[T any](t TB, v T, err error, c CondT[T]) {
+	t.Helper()
+	if err != nil {
+		e := Equals(nil).SetMessage(msgf("unexpected error <%v>", err))
+		if cond.Fatal(c.Cond) {
+			e.SetFatal()
+		}
+		t.Assert(err, e)
+		return
+	}
+	t.Assert(v, c.Cond)
+}
@@ -0,0 +1,97 @@
+package asserting_test
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestEqualsT(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	AssertT(t, 1+1, 2)
+	AssertT(t, time.Second, 1000*time.Millisecond)
+	AssertT[error](t, nil, nil)
+	t.Assert("a", EqualsT("a"))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	AssertT(t, 1, 2)
+	AssertT[error](t, io.EOF, nil)
+	t.Assert(int64(1), EqualsT(1))
+	t.Assert(nil, EqualsT(0))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "expected <2> but was <1>" ||
+		mock.ErrorMessages[1][0] != "expected <<nil>> but was <EOF>" ||
+		mock.ErrorMessages[2][0] != "expected type <int> but was <1(int64)>" ||
+		mock.ErrorMessages[3][0] != "expected type <int> but was <<nil>(<nil>)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestMust(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	if v := Must(t, 1, nil); v != 1 {
+		t1.Fatal(v)
+	}
+	if s := MustValue(fmt.Sprint("a"), error(nil))(t); s != "a" {
+		t1.Fatal(s)
+	}
+	if n := MustValue(strconv.Atoi("42"))(t); n != 42 {
+		t1.Fatal(n)
+	}
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.FatalMessages)
+	}
+
+	Must(t, 0, io.EOF)
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 1 || mock.FatalMessages[0][0] != "unexpected error <EOF>" {
+		t1.Fatal(mock.ErrorMessages, mock.FatalMessages)
+	}
+
+	mock = &MockTB{TB: t1}
+	MustValue(strconv.Atoi("x"))(NewTB(mock))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 1 ||
+		mock.FatalMessages[0][0] != `unexpected error <strconv.Atoi: parsing "x": invalid syntax>` {
+		t1.Fatal(mock.ErrorMessages, mock.FatalMessages)
+	}
+}
+
+func TestAssertOK(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	n, err := strconv.Atoi("42")
+	AssertOK(t, n, err, Typed[int](Equals(42)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	AssertOK(t, n, err, Typed[int](Equals(43)))
+	n, err = strconv.Atoi("x")
+	AssertOK(t, n, err, Typed[int](Equals(0)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected <43> but was <42>" ||
+		mock.ErrorMessages[1][0] != `unexpected error <strconv.Atoi: parsing "x": invalid syntax>` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	AssertOK(t, n, err, Typed[int](Equals(0).SetFatal()))
+	if len(mock.FatalMessages) != 1 {
+		t1.Fatal(mock.FatalMessages)
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/mkch/asserting
 
-go 1.12
+go 1.21
+
+require github.com/google/go-cmp v0.7.0
@@ -0,0 +1,125 @@
+package asserting
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// updateGolden returns whether golden files should be updated,
+// which is the case if the test binary defines a bool flag -update and it is set.
+func updateGolden() bool {
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	g, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	update, _ := g.Get().(bool)
+	return update
+}
+
+type readerEqualsGolden struct {
+	path      string
+	scrubbers []Scrubber
+	msg       string // The failure message of the last Test.
+}
+
+// ReaderEqualsGolden returns a cond which is true if the content read from the tested
+// io.Reader equals to the content of the golden file at path. The contents are compared
+// while being read, so they are never held in memory as a whole, unless the content read
+// has to be scrubbed first, by the Scrubbers registered with RegisterScrubber, and then
+// scrubbers.
+//
+// If the test binary defines a bool flag -update, as in
+//
+//	var _ = flag.Bool("update", false, "update golden files")
+//
+// and the tests are run with -update, the golden file is overwritten with the scrubbed
+// content read from the reader instead, and the cond is true.
+func ReaderEqualsGolden(path string, scrubbers ...Scrubber) cond.Cond {
+	return cond.New(&readerEqualsGolden{path: path, scrubbers: scrubbers})
+}
+
+func (c *readerEqualsGolden) Test(v interface{}) bool {
+	c.msg = c.test(v)
+	return c.msg == ""
+}
+
+func (c *readerEqualsGolden) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *readerEqualsGolden) Kind() string {
+	return "ReaderEqualsGolden"
+}
+
+// test returns the failure message, or an empty string if c is met.
+func (c *readerEqualsGolden) test(v interface{}) string {
+	r, ok := v.(io.Reader)
+	if !ok {
+		return msgf("<%[1]v(%[1]T)> is not an io.Reader", v)
+	}
+	if scrubbers := append(registeredScrubbers(), c.scrubbers...); len(scrubbers) > 0 {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return msgf("error reading value: %v", err)
+		}
+		r = strings.NewReader(scrub(string(content), scrubbers))
+	}
+	if updateGolden() {
+		if err := writeGolden(c.path, r); err != nil {
+			return msgf("can't update golden file %v: %v", c.path, err)
+		}
+		return ""
+	}
+	f, err := os.Open(c.path)
+	if err != nil {
+		return msgf("can't open golden file: %v", err)
+	}
+	defer f.Close()
+	return compareReaders(bufio.NewReader(f), bufio.NewReader(r), c.path)
+}
+
+func writeGolden(path string, r io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// compareReaders compares the content of expected and actual byte by byte, and
+// returns the failure message, or an empty string if the contents are equal.
+func compareReaders(expected, actual io.ByteReader, name string) string {
+	for off := 0; ; off++ {
+		b1, err1 := expected.ReadByte()
+		b2, err2 := actual.ReadByte()
+		if err1 != nil && err1 != io.EOF {
+			return msgf("error reading %v: %v", name, err1)
+		}
+		if err2 != nil && err2 != io.EOF {
+			return msgf("error reading value: %v", err2)
+		}
+		switch {
+		case err1 == io.EOF && err2 == io.EOF:
+			return ""
+		case err1 == io.EOF:
+			return msgf("content is longer than %v, which ends at offset %v", name, off)
+		case err2 == io.EOF:
+			return msgf("content ends at offset %v, before the end of %v", off, name)
+		case b1 != b2:
+			return msgf("content differs from %v at offset %v: expected <%#02x> but was <%#02x>", name, off, b1, b2)
+		}
+	}
+}
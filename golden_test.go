@@ -0,0 +1,55 @@
+package asserting_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestReaderEqualsGolden(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(strings.NewReader("hello, golden\n"), ReaderEqualsGolden("testdata/hello.golden"))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(strings.NewReader("hello, Golden\n"), ReaderEqualsGolden("testdata/hello.golden"))
+	t.Assert(strings.NewReader("hello, golden\n!"), ReaderEqualsGolden("testdata/hello.golden"))
+	t.Assert(strings.NewReader("hello"), ReaderEqualsGolden("testdata/hello.golden"))
+	t.Assert("hello", ReaderEqualsGolden("testdata/hello.golden"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "content differs from testdata/hello.golden at offset 7: expected <0x67> but was <0x47>" ||
+		mock.ErrorMessages[1][0] != "content is longer than testdata/hello.golden, which ends at offset 14" ||
+		mock.ErrorMessages[2][0] != "content ends at offset 5, before the end of testdata/hello.golden" ||
+		mock.ErrorMessages[3][0] != "<hello(string)> is not an io.Reader" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestReaderEqualsGoldenUpdate(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	path := filepath.Join(t1.TempDir(), "new.golden")
+	fs := flag.CommandLine
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	defer func() { flag.CommandLine = fs }()
+	flag.Bool("update", true, "")
+
+	t.Assert(strings.NewReader("new content"), ReaderEqualsGolden(path))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+	if content, err := os.ReadFile(path); err != nil || string(content) != "new content" {
+		t1.Fatal(string(content), err)
+	}
+}
@@ -0,0 +1,145 @@
+package asserting
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// goroutineSettle is the max time waited for goroutines started by the tested
+// function to exit.
+const goroutineSettle = time.Second
+
+type goroutineDelta struct {
+	max int
+	msg string // The failure message of the last Test.
+}
+
+// GoroutineDelta returns a cond which is true if calling the tested function
+// increases the number of goroutines by at most max. Goroutines are given up to
+// one second to exit after the function returns.
+// The cond is false if the tested value is not of type func().
+func GoroutineDelta(max int) cond.Cond {
+	return cond.New(&goroutineDelta{max: max})
+}
+
+func (c *goroutineDelta) Test(v interface{}) bool {
+	f, ok := v.(func())
+	if !ok {
+		c.msg = notFuncMsg(v)
+		return false
+	}
+	before := runtime.NumGoroutine()
+	f()
+	delta := runtime.NumGoroutine() - before
+	for wait, deadline := time.Millisecond, time.Now().Add(goroutineSettle); delta > c.max && time.Now().Before(deadline); wait *= 2 {
+		time.Sleep(wait)
+		delta = runtime.NumGoroutine() - before
+	}
+	if delta <= c.max {
+		c.msg = ""
+		return true
+	}
+	c.msg = msgf("goroutine count increased by %v, expected at most %v (%v before, %v after)", delta, c.max, before, before+delta)
+	return false
+}
+
+func (c *goroutineDelta) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *goroutineDelta) Kind() string {
+	return "GoroutineDelta"
+}
+
+// AssertGoroutineDelta calls t.Assert(f, GoroutineDelta(max)).
+func (t TB) AssertGoroutineDelta(max int, f func()) {
+	t.Helper()
+	t.Assert(f, GoroutineDelta(max))
+}
+
+// goTB is a testing.TB used in goroutines started by TB.Go. Failures are reported
+// through the underlying testing.TB, one at a time. Fatal and FailNow stop the
+// goroutine only.
+type goTB struct {
+	testing.TB
+	mu    sync.Mutex
+	fatal bool // Whether Fatal or FailNow has been called.
+}
+
+func (g *goTB) unwrap() testing.TB {
+	return g.TB
+}
+
+func (g *goTB) Error(args ...interface{}) {
+	g.TB.Helper()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.TB.Error(args...)
+}
+
+func (g *goTB) Errorf(format string, args ...interface{}) {
+	g.TB.Helper()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.TB.Errorf(format, args...)
+}
+
+func (g *goTB) Fatal(args ...interface{}) {
+	g.TB.Helper()
+	g.Error(args...)
+	g.FailNow()
+}
+
+func (g *goTB) Fatalf(format string, args ...interface{}) {
+	g.TB.Helper()
+	g.Errorf(format, args...)
+	g.FailNow()
+}
+
+func (g *goTB) Fail() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.TB.Fail()
+}
+
+func (g *goTB) FailNow() {
+	g.mu.Lock()
+	g.fatal = true
+	g.TB.Fail()
+	g.mu.Unlock()
+	runtime.Goexit()
+}
+
+// goWait is the max time the cleanup of TB.Go waits for the goroutine to exit.
+var goWait = 10 * time.Second
+
+// Go calls f with a TB in a new goroutine, which is safe to assert in, unlike t itself
+// whose Fatal must be called from the test goroutine only. Failures reported through
+// the TB are reported as errors, and Fatal stops the goroutine instead of the test.
+// The test waits up to 10 seconds for the goroutine to exit when it finishes, and
+// reports an error if Fatal has been called in the goroutine, or it has not exited.
+func (t TB) Go(f func(t TB)) {
+	g := &goTB{TB: t.TB}
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		timer := time.NewTimer(goWait)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+			g.TB.Error(msgf("goroutine started by Go has not exited in %v", goWait))
+			return
+		}
+		if g.fatal {
+			g.TB.Error(msgf("goroutine started by Go stopped by Fatal"))
+		}
+	})
+	go func() {
+		defer close(done)
+		f(TB{g})
+	}()
+}
@@ -0,0 +1,81 @@
+package asserting_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestAssertGoroutineDelta(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertGoroutineDelta(0, func() {})
+	t.AssertGoroutineDelta(0, func() {
+		go time.Sleep(10 * time.Millisecond)
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	t.AssertGoroutineDelta(1, func() {
+		go func() { <-stop }()
+	})
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertGoroutineDelta(0, func() {
+		go func() { <-stop }()
+	})
+	t.Assert(1, GoroutineDelta(0))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		!strings.HasPrefix(mock.ErrorMessages[0][0].(string), "goroutine count increased by 1, expected at most 0 (") ||
+		mock.ErrorMessages[1][0] != "<1(int)> is not a func()" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestGo(t1 *testing.T) {
+	var mock *mocktb.TB
+	var finished bool
+	t1.Run("", func(t2 *testing.T) {
+		mock = mocktb.New(t2)
+		t := NewTB(mock)
+		t.Go(func(t TB) {
+			time.Sleep(10 * time.Millisecond)
+			t.AssertEqual(1, 2)
+			t.Assert(1, Equals(3).SetFatal())
+			finished = true
+		})
+		t.Go(func(t TB) {
+			t.AssertEqual(1, 1)
+		})
+	})
+	if finished ||
+		!reflect.DeepEqual(mock.Errors(), []string{"expected <2> but was <1>", "expected <3> but was <1>", "goroutine started by Go stopped by Fatal"}) ||
+		len(mock.Fatals()) != 0 || !mock.Failed() {
+		t1.Fatal(mock.Errors(), mock.Fatals(), finished)
+	}
+}
+
+func TestGoNotExited(t1 *testing.T) {
+	defer func(wait time.Duration) { *GoWait = wait }(*GoWait)
+	*GoWait = 10 * time.Millisecond
+	stop := make(chan struct{})
+	defer close(stop)
+
+	var mock *mocktb.TB
+	t1.Run("", func(t2 *testing.T) {
+		mock = mocktb.New(t2)
+		NewTB(mock).Go(func(t TB) { <-stop })
+	})
+	if !reflect.DeepEqual(mock.Errors(), []string{"goroutine started by Go has not exited in 10ms"}) || len(mock.Fatals()) != 0 {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+}
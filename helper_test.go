@@ -0,0 +1,74 @@
+package asserting_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func assertTwo(t TB, v int) {
+	t.Helper()
+	t.Assert(v, Equals(2))
+}
+
+func assertTwoIndirectly(t TB, v int) {
+	t.Helper()
+	assertTwo(t, v)
+}
+
+func assertTwoT(t *testing.T, v int) {
+	t.Helper()
+	NewTB(t).Assert(v, Equals(2))
+}
+
+type projectTB struct {
+	TB
+}
+
+func (t projectTB) assertTwo(v int) {
+	t.Helper()
+	assert := t.Assert
+	assert(v, Equals(2))
+}
+
+// TestHelperAttribution runs itself in a child process, and checks that failures
+// are reported at the lines marked with "// Fails here.", not in the helpers.
+func TestHelperAttribution(t1 *testing.T) {
+	if os.Getenv("ASSERTING_HELPER_TEST") == "1" {
+		t := NewTB(t1)
+		assertTwoIndirectly(t, 1) // Fails here.
+		projectTB{t}.assertTwo(1) // Fails here.
+		wrapped := t.WithContext("ctx").WithMaxErrors(10).WithFailureSummary()
+		assertTwoT(wrapped.Unwrap().(*testing.T), 1) // Fails here.
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperAttribution$")
+	cmd.Env = append(os.Environ(), "ASSERTING_HELPER_TEST=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t1.Fatalf("child test passed:\n%s", out)
+	}
+
+	src, err := os.ReadFile("helper_test.go")
+	if err != nil {
+		t1.Fatal(err)
+	}
+	var found int
+	for i, line := range strings.Split(string(src), "\n") {
+		if !strings.HasSuffix(line, " // Fails here.") {
+			continue
+		}
+		found++
+		if expected := fmt.Sprintf("helper_test.go:%v: expected <2> but was <1>", i+1); !strings.Contains(string(out), expected) {
+			t1.Errorf("%q not found in child test output:\n%s", expected, out)
+		}
+	}
+	if found != 3 {
+		t1.Fatal(found)
+	}
+}
@@ -0,0 +1,81 @@
+package asserting
+
+import (
+	"sync"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// FailureInfo describes a failed assertion passed to the hooks registered with
+// TB.OnFailure.
+type FailureInfo struct {
+	Failure
+	// Cond is the failed cond.
+	Cond cond.Cond
+	// Test is the name of the test, see testing.TB.Name.
+	Test string
+	// Fatal is whether the failure is going to be reported with Fatal.
+	Fatal bool
+	// File and Line are the location of the assertion, which is the first
+	// caller outside this package.
+	File string
+	Line int
+}
+
+// hooks maps test names to the []func(*FailureInfo) registered in the tests.
+var hooks struct {
+	mu    sync.Mutex
+	tests map[string][]func(*FailureInfo)
+}
+
+// OnFailure registers hook to be called with the failure info when an assertion
+// made with t, or another TB of the same test, excluding subtests, fails, before the
+// failure is reported. Hooks are called in the order registered, and can be used to
+// attach artifacts, dump goroutines or forward failures to external systems.
+// Hooks are unregistered when the test finishes.
+func (t TB) OnFailure(hook func(info *FailureInfo)) {
+	name := t.Name()
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	if hooks.tests == nil {
+		hooks.tests = make(map[string][]func(*FailureInfo))
+	}
+	if len(hooks.tests[name]) == 0 {
+		t.Cleanup(func() {
+			hooks.mu.Lock()
+			defer hooks.mu.Unlock()
+			delete(hooks.tests, name)
+		})
+	}
+	hooks.tests[name] = append(hooks.tests[name], hook)
+}
+
+// callHooks calls the hooks of test with the failure info of v failing c,
+// in the assertion named name. fatal is whether the failure is going to be reported
+// with Fatal.
+func callHooks(test, name string, c cond.Cond, v interface{}, msg string, fatal bool) {
+	hooks.mu.Lock()
+	list := hooks.tests[test]
+	hooks.mu.Unlock()
+	if len(list) == 0 {
+		return
+	}
+	expected, hasExpected := cond.Expected(c)
+	info := &FailureInfo{
+		Failure: Failure{
+			Name:        name,
+			Kind:        cond.Kind(c),
+			Expected:    expected,
+			HasExpected: hasExpected,
+			Actual:      v,
+			Message:     msg,
+		},
+		Cond:  c,
+		Test:  test,
+		Fatal: fatal,
+	}
+	info.File, info.Line = caller()
+	for _, hook := range list {
+		hook(info)
+	}
+}
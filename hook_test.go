@@ -0,0 +1,66 @@
+package asserting_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestOnFailure(t1 *testing.T) {
+	var infos []*FailureInfo
+	var calls []string
+	var line int
+	var mock *mocktb.TB
+	t1.Run("", func(t2 *testing.T) {
+		mock = mocktb.New(t2)
+		t := NewTB(mock)
+		t.OnFailure(func(info *FailureInfo) {
+			if len(mock.Errors()) != len(infos) {
+				t1.Error("hook called after reporting")
+			}
+			infos = append(infos, info)
+			calls = append(calls, "1")
+		})
+		t.OnFailure(func(info *FailureInfo) { calls = append(calls, "2") })
+
+		t.AssertEqual(1, 1)
+		_, _, line, _ = runtime.Caller(0)
+		t.AssertNamed("id", 1, Equals(2))
+		t.Assert(3, IsNil())
+	})
+
+	if len(infos) != 2 || len(calls) != 4 || calls[0] != "1" || calls[1] != "2" {
+		t1.Fatal(infos, calls)
+	}
+	info := infos[0]
+	if info.Name != "id" || info.Kind != "Equals" || !info.HasExpected || info.Expected != 2 || info.Actual != 1 ||
+		info.Message != "expected <2> but was <1>" || info.Test != "TestOnFailure/#00" || info.Fatal ||
+		info.Line != line+1 || info.Cond == nil {
+		t1.Fatal(info)
+	}
+	if info := infos[1]; info.Kind != "IsNil" || info.HasExpected || info.Line != line+2 {
+		t1.Fatal(info)
+	}
+
+	t := NewTB(mocktb.New(t1))
+	t.AssertEqual(1, 2) // No hooks registered in this test.
+	if len(infos) != 2 {
+		t1.Fatal(infos)
+	}
+}
+
+func TestOnFailureRequire(t1 *testing.T) {
+	var fatals []bool
+	t1.Run("", func(t2 *testing.T) {
+		t := NewTB(mocktb.New(t2))
+		t.OnFailure(func(info *FailureInfo) { fatals = append(fatals, info.Fatal) })
+		t.AssertEqual(1, 2)
+		t.Require().AssertEqual(1, 2)
+		t.Require().WithContext("ctx").AssertEqual(1, 2)
+	})
+	if len(fatals) != 3 || fatals[0] || !fatals[1] || !fatals[2] {
+		t1.Fatal(fatals)
+	}
+}
@@ -0,0 +1,259 @@
+package asserting
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// Client is an HTTP client of a test server started by TB.ServeHTTP.
+// Requests failing to get a response are reported with Fatal.
+type Client struct {
+	*http.Client
+	// URL is the base URL of the server, e.g. "http://127.0.0.1:1234".
+	URL string
+	t   TB
+}
+
+// ServeHTTP starts an httptest.Server serving handler, which is closed in Cleanup,
+// and returns a client of it.
+func (t TB) ServeHTTP(handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{Client: server.Client(), URL: server.URL, t: t}
+}
+
+// Do sends req, resolving a relative req.URL against c.URL.
+// The response body is closed in Cleanup.
+func (c *Client) Do(req *http.Request) *http.Response {
+	c.t.Helper()
+	if !req.URL.IsAbs() {
+		base, err := url.Parse(c.URL)
+		if err != nil {
+			c.t.Fatal(err)
+			return nil
+		}
+		req.URL = base.ResolveReference(req.URL)
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+		return nil
+	}
+	c.t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// Get issues a GET to path, relative to c.URL.
+func (c *Client) Get(path string) *http.Response {
+	c.t.Helper()
+	return c.send(http.MethodGet, path, "", nil)
+}
+
+// Post issues a POST of body with content type contentType to path, relative to c.URL.
+func (c *Client) Post(path, contentType, body string) *http.Response {
+	c.t.Helper()
+	return c.send(http.MethodPost, path, contentType, strings.NewReader(body))
+}
+
+func (c *Client) send(method, path, contentType string, body io.Reader) *http.Response {
+	c.t.Helper()
+	req, err := http.NewRequest(method, path, body)
+	if err != nil {
+		c.t.Fatal(err)
+		return nil
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.Do(req)
+}
+
+// AssertHandler serves req with handler using an httptest.ResponseRecorder, and asserts
+// the response, an *http.Response, with conds in order, e.g.
+//
+//	t.AssertHandler(handler, httptest.NewRequest("GET", "/", nil), HasStatus(200), HasBody(Equals("ok")))
+//
+// The response body is captured, and replayed for every cond, so each cond can read it
+// from the beginning. The response is returned with the body replayable in the same way.
+func (t TB) AssertHandler(handler http.Handler, req *http.Request, conds ...cond.Cond) *http.Response {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	resp := rec.Result()
+	body := rec.Body.Bytes()
+	for _, c := range conds {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		t.Assert(resp, c)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp
+}
+
+type hasStatus struct {
+	code int
+}
+
+// HasStatus returns a cond which is true if the status code of the tested *http.Response,
+// or *httptest.ResponseRecorder, is code.
+func HasStatus(code int) cond.Cond {
+	return cond.New(&hasStatus{code: code})
+}
+
+func (c *hasStatus) Test(v interface{}) bool {
+	code, ok := statusCode(v)
+	return ok && code == c.code
+}
+
+func (c *hasStatus) Message(v interface{}) string {
+	code, ok := statusCode(v)
+	if !ok {
+		return notResponseMsg(v)
+	}
+	return msgf("expected status <%v %v> but was <%v %v>", c.code, http.StatusText(c.code), code, http.StatusText(code))
+}
+
+func (c *hasStatus) Kind() string {
+	return "HasStatus"
+}
+
+func (c *hasStatus) Expected() interface{} {
+	return c.code
+}
+
+func statusCode(v interface{}) (code int, ok bool) {
+	switch r := v.(type) {
+	case *http.Response:
+		if r != nil {
+			return r.StatusCode, true
+		}
+	case *httptest.ResponseRecorder:
+		if r != nil {
+			return r.Code, true
+		}
+	}
+	return 0, false
+}
+
+// notResponseMsg returns the failure message of v, which is not a non-nil *http.Response
+// or *httptest.ResponseRecorder.
+func notResponseMsg(v interface{}) string {
+	switch v.(type) {
+	case *http.Response, *httptest.ResponseRecorder:
+		return msgf("nil response <%T>", v)
+	}
+	return msgf("<%[1]v(%[1]T)> is not an *http.Response or *httptest.ResponseRecorder", v)
+}
+
+type hasHeader struct {
+	name string
+	c    cond.Cond
+}
+
+// HasHeader returns a cond which is true if the tested *http.Response, or
+// *httptest.ResponseRecorder, has the header name, and the first value of the header,
+// a string, meets c. A nil c accepts any value.
+func HasHeader(name string, c cond.Cond) cond.Cond {
+	return cond.New(&hasHeader{name: name, c: c})
+}
+
+func (c *hasHeader) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *hasHeader) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *hasHeader) Kind() string {
+	return "HasHeader"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *hasHeader) Check(v interface{}) string {
+	var header http.Header
+	switch r := v.(type) {
+	case *http.Response:
+		if r != nil {
+			header = r.Header
+		}
+	case *httptest.ResponseRecorder:
+		if r != nil {
+			header = r.Result().Header
+		}
+	}
+	if header == nil {
+		return notResponseMsg(v)
+	}
+	values := header.Values(c.name)
+	if len(values) == 0 {
+		return msgf("no header %v", http.CanonicalHeaderKey(c.name))
+	}
+	if c.c != nil {
+		if ok, msg := cond.Check(c.c, values[0]); !ok {
+			return msgf("header %v: %v", http.CanonicalHeaderKey(c.name), msg)
+		}
+	}
+	return ""
+}
+
+type hasBody struct {
+	c cond.Cond
+}
+
+// HasBody returns a cond which is true if the body of the tested *http.Response, or
+// *httptest.ResponseRecorder, read as a string, meets c. A nil c accepts any body.
+// The body of an *http.Response is read, closed, and replaced with a copy, so that it
+// can be read again.
+func HasBody(c cond.Cond) cond.Cond {
+	return cond.New(&hasBody{c: c})
+}
+
+func (c *hasBody) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *hasBody) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *hasBody) Kind() string {
+	return "HasBody"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *hasBody) Check(v interface{}) string {
+	var body string
+	switch r := v.(type) {
+	case *http.Response:
+		if r == nil {
+			return notResponseMsg(v)
+		}
+		b, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			return msgf("error reading body: %v", err)
+		}
+		body = string(b)
+	case *httptest.ResponseRecorder:
+		if r == nil {
+			return notResponseMsg(v)
+		}
+		body = r.Body.String()
+	default:
+		return notResponseMsg(v)
+	}
+	if c.c != nil {
+		if ok, msg := cond.Check(c.c, body); !ok {
+			return msgf("body: %v", msg)
+		}
+	}
+	return ""
+}
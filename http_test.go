@@ -0,0 +1,120 @@
+package asserting_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/cond"
+)
+
+func TestServeHTTP(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	c := t.ServeHTTP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		io.WriteString(w, r.Method+" "+r.URL.Path+" "+string(body))
+	}))
+	resp := c.Get("/hello")
+	t.AssertEqual(resp.StatusCode, http.StatusOK)
+	t.AssertEqual(readAll(resp.Body), "GET /hello ")
+
+	resp = c.Post("/items", "text/plain", "abc")
+	t.AssertEqual(resp.Header.Get("Content-Type"), "text/plain")
+	t.AssertEqual(readAll(resp.Body), "POST /items abc")
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages, mock.FatalMessages)
+	}
+
+	c.Get("%zz")
+	if len(mock.FatalMessages) != 1 {
+		t1.Fatal(mock.FatalMessages)
+	}
+}
+
+func TestAssertHandler(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id": 42}`)
+	})
+	resp := t.AssertHandler(handler, httptest.NewRequest("GET", "/", nil),
+		HasStatus(http.StatusOK),
+		HasHeader("content-type", Equals("application/json")),
+		HasBody(JSONPath("$.id", Equals(42))),
+		HasBody(MatchesRegexp(`^\{"id"`)))
+	t.AssertEqual(readAll(resp.Body), `{"id": 42}`)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	t.Assert(rec, HasStatus(http.StatusOK))
+	t.Assert(rec, HasHeader("Content-Type", nil))
+	t.Assert(rec, HasBody(JSONEq(`{"id":42}`)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages, mock.FatalMessages)
+	}
+
+	t.AssertHandler(handler, httptest.NewRequest("GET", "/x", nil),
+		HasStatus(http.StatusOK),
+		HasHeader("Content-Type", Equals("application/json")),
+		HasHeader("ETag", nil),
+		HasBody(Equals("")))
+	t.Assert(1, HasStatus(http.StatusOK))
+	t.Assert((*http.Response)(nil), HasStatus(http.StatusOK))
+	t.Assert((*httptest.ResponseRecorder)(nil), HasStatus(http.StatusOK))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 7 ||
+		mock.ErrorMessages[0][0] != "expected status <200 OK> but was <404 Not Found>" ||
+		mock.ErrorMessages[1][0] != `header Content-Type: expected <"application/json"> but was <"text/plain; charset=utf-8">` ||
+		mock.ErrorMessages[2][0] != "no header Etag" ||
+		mock.ErrorMessages[3][0] != `body: expected <""> but was <"404 page not found\n">` ||
+		mock.ErrorMessages[4][0] != "<1(int)> is not an *http.Response or *httptest.ResponseRecorder" ||
+		mock.ErrorMessages[5][0] != "nil response <*http.Response>" ||
+		mock.ErrorMessages[6][0] != "nil response <*httptest.ResponseRecorder>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func readAll(r io.Reader) string {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+func TestHasBody(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	newResponse := func() *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("abc"))}
+	}
+	resp := newResponse()
+	t.Assert(resp, HasBody(nil))
+	t.Assert(resp, HasBody(Equals("abc")))
+	t.AssertEqual(readAll(resp.Body), "abc")
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	if msg := cond.Message(HasBody(Equals("x")), newResponse()); msg != `body: expected <"x"> but was <"abc">` {
+		t1.Fatal(msg)
+	}
+	t.Assert((*http.Response)(nil), HasBody(nil))
+	if len(mock.ErrorMessages) != 1 || mock.ErrorMessages[0][0] != "nil response <*http.Response>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
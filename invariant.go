@@ -0,0 +1,109 @@
+package asserting
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// invariantSet is the invariants registered in a test and its parent test.
+type invariantSet struct {
+	mu         sync.Mutex
+	parent     string // Name of the parent test, empty if none.
+	names      []string
+	checks     []func() error
+	registered bool // Whether the set is checked in Cleanup.
+}
+
+// invariants maps test names to *invariantSet.
+var invariants sync.Map
+
+// invariantsOf returns the invariantSet of t, creating an empty one if none.
+func invariantsOf(t testing.TB) *invariantSet {
+	s, _ := invariants.LoadOrStore(t.Name(), &invariantSet{})
+	return s.(*invariantSet)
+}
+
+// Invariant registers an invariant named name. check is called after the test and
+// after each subtest created through t.Run, including nested ones, finishes.
+// A non-nil error returned by check is reported as a failure, naming the invariant
+// and the test in which it broke.
+func (t TB) Invariant(name string, check func() error) {
+	t.Helper()
+	s := invariantsOf(t.TB)
+	s.mu.Lock()
+	s.names = append(s.names, name)
+	s.checks = append(s.checks, check)
+	s.mu.Unlock()
+	t.checkInvariants(s)
+}
+
+// checkInvariants registers a Cleanup of t checking the invariants of s and its
+// ancestors, if not registered yet.
+func (t TB) checkInvariants(s *invariantSet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.registered {
+		return
+	}
+	s.registered = true
+	t.Cleanup(func() {
+		t.Helper()
+		defer invariants.Delete(t.Name())
+		for name := t.Name(); name != ""; {
+			v, ok := invariants.Load(name)
+			if !ok {
+				break
+			}
+			s := v.(*invariantSet)
+			s.mu.Lock()
+			names, checks, parent := s.names, s.checks, s.parent
+			s.mu.Unlock()
+			for i, check := range checks {
+				if err := check(); err != nil {
+					t.Error(msgf("invariant %q broken after %v: %v", names[i], t.Name(), err))
+				}
+			}
+			name = parent
+		}
+	})
+}
+
+var tbType = reflect.TypeOf((*testing.TB)(nil)).Elem()
+
+// Run runs f as a subtest of t called name, as *testing.T.Run and *testing.B.Run do,
+// and checks the invariants registered in t and its ancestors after f finishes.
+// The underlying testing.TB must have a method Run(string, func(T)) bool, where T
+// implements testing.TB. Run panics otherwise.
+// Run reports whether f succeeded.
+func (t TB) Run(name string, f func(t TB)) bool {
+	t.Helper()
+	run := reflect.ValueOf(t.TB).MethodByName("Run")
+	if !run.IsValid() || !isRunMethod(run.Type()) {
+		panic(fmt.Sprintf("%T has no method Run(string, func(testing.TB)) bool", t.TB))
+	}
+	parent := t.Name()
+	body := reflect.MakeFunc(run.Type().In(1), func(args []reflect.Value) []reflect.Value {
+		sub := NewTB(args[0].Interface().(testing.TB))
+		s := invariantsOf(sub.TB)
+		s.mu.Lock()
+		s.parent = parent
+		s.mu.Unlock()
+		sub.checkInvariants(s)
+		f(sub)
+		return nil
+	})
+	return run.Call([]reflect.Value{reflect.ValueOf(name), body})[0].Bool()
+}
+
+// isRunMethod returns whether typ is of func(string, func(T)) bool, where T
+// implements testing.TB.
+func isRunMethod(typ reflect.Type) bool {
+	if typ.NumIn() != 2 || typ.In(0).Kind() != reflect.String || typ.In(1).Kind() != reflect.Func ||
+		typ.NumOut() != 1 || typ.Out(0).Kind() != reflect.Bool {
+		return false
+	}
+	f := typ.In(1)
+	return f.NumIn() == 1 && f.NumOut() == 0 && f.In(0).Implements(tbType)
+}
@@ -0,0 +1,63 @@
+package asserting_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+// runnerTB records errors, and runs subtests recording errors to the same slice.
+type runnerTB struct {
+	*testing.T
+	errors *[]string
+}
+
+func (r runnerTB) Error(args ...interface{}) {
+	*r.errors = append(*r.errors, fmt.Sprint(args...))
+}
+
+func (r runnerTB) Run(name string, f func(r runnerTB)) bool {
+	return r.T.Run(name, func(t *testing.T) { f(runnerTB{t, r.errors}) })
+}
+
+func TestInvariant(t1 *testing.T) {
+	var errs []string
+	balance := 0
+	t1.Run("inner", func(t2 *testing.T) {
+		t := NewTB(runnerTB{t2, &errs})
+		t.Invariant("non-negative balance", func() error {
+			if balance < 0 {
+				return errors.New("negative balance")
+			}
+			return nil
+		})
+		t.Run("deposit", func(t TB) { balance += 10 })
+		t.Run("withdraw", func(t TB) {
+			t.Invariant("at most 5", func() error {
+				if balance > 5 {
+					return fmt.Errorf("balance %v", balance)
+				}
+				return nil
+			})
+			balance -= 20
+		})
+		t.Run("refund", func(t TB) { balance += 10 })
+	})
+	if len(errs) != 1 ||
+		errs[0] != `invariant "non-negative balance" broken after TestInvariant/inner/withdraw: negative balance` {
+		t1.Fatal(errs)
+	}
+
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+	func() {
+		defer func() {
+			if r := recover(); r != "*asserting_test.MockTB has no method Run(string, func(testing.TB)) bool" {
+				t1.Fatal(r)
+			}
+		}()
+		t.Run("sub", func(t TB) {})
+	}()
+}
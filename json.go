@@ -0,0 +1,266 @@
+package asserting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// JSONEq returns a cond which is true if the tested JSON document equals to expected
+// semantically, ignoring the order of object keys and whitespace. Both documents are
+// strings or []byte. The failure message lists the path of every difference, e.g.
+// `["items"][2]["price"]: expected <100> but was <90>`.
+// JSONEq panics if expected is not a valid JSON document.
+func JSONEq(expected interface{}) cond.Cond {
+	return DocumentEq("JSON", parseJSON, expected)
+}
+
+// parseJSON parses JSON document s into a value of basic types, as json.Unmarshal
+// does with an interface{}.
+func parseJSON(s string) (v interface{}, err error) {
+	err = json.Unmarshal([]byte(s), &v)
+	return
+}
+
+type documentEq struct {
+	format         string
+	parse          func(s string) (interface{}, error)
+	expected       string
+	expectedParsed interface{}
+}
+
+// DocumentEq returns a cond which is true if the tested document equals to expected
+// semantically, as they are parsed by parse into values of basic types, such as maps,
+// slices, strings and numbers. Both documents are strings or []byte. format is the
+// name of the format, e.g. "JSON", used in the failure messages and the kind of the
+// cond, e.g. "JSONEq". The failure message lists the path of every difference, as
+// JSONEq does. DocumentEq is the building block of conds of other formats, such as
+// YAML, without this package depending on their parsers.
+// DocumentEq panics if expected is not a string or []byte, or fails to parse.
+func DocumentEq(format string, parse func(s string) (interface{}, error), expected interface{}) cond.Cond {
+	s, ok := stringValue(expected)
+	if !ok {
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a string", expected))
+	}
+	parsed, err := parse(s)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %v <%v>: %v", format, s, err))
+	}
+	return cond.New(&documentEq{format: format, parse: parse, expected: s, expectedParsed: parsed})
+}
+
+func (c *documentEq) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *documentEq) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *documentEq) Check(v interface{}) string {
+	s, ok := stringValue(v)
+	if !ok {
+		return msgf("<%[1]v(%[1]T)> is not a string", v)
+	}
+	parsed, err := c.parse(s)
+	if err != nil {
+		return msgf("invalid "+c.format+" <%v>: %v", s, err)
+	}
+	if d := diff(c.expectedParsed, parsed, true); d != "" {
+		return msgf(c.format+" differs:\n%v", d)
+	}
+	return ""
+}
+
+func (c *documentEq) Kind() string {
+	return c.format + "Eq"
+}
+
+func (c *documentEq) Expected() interface{} {
+	return c.expected
+}
+
+// AssertJSONEq calls t.Assert(actual, JSONEq(expected)).
+func (t TB) AssertJSONEq(actual, expected interface{}) {
+	t.Helper()
+	t.Assert(actual, JSONEq(expected))
+}
+
+type jsonPath struct {
+	path  string
+	steps []interface{} // Object keys of type string and array indexes of type int.
+	c     cond.Cond
+}
+
+// JSONPath returns a cond which is true if the value at path of the tested JSON document
+// meets c, e.g.
+//
+//	JSONPath("$.items[0].id", Equals(42))
+//
+// path starts with "$", which selects the whole document, followed by steps selecting
+// object members by ".name" or `["name"]`, and array elements by "[index]".
+// The tested document is a string or []byte, or a value already decoded, such as a
+// map[string]interface{}. Numbers are integral values of type int, if they fit,
+// or float64 otherwise, so that literals can be expected as is.
+// JSONPath panics if path is invalid.
+func JSONPath(path string, c cond.Cond) cond.Cond {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		panic(fmt.Sprintf("invalid JSON path %q: %v", path, err))
+	}
+	return cond.New(&jsonPath{path: path, steps: steps, c: c})
+}
+
+var jsonPathName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+
+// parseJSONPath parses path into object keys and array indexes.
+func parseJSONPath(path string) (steps []interface{}, err error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("missing $")
+	}
+	for rest := path[1:]; rest != ""; {
+		switch {
+		case rest[0] == '.' && jsonPathName.MatchString(rest[1:]):
+			name := jsonPathName.FindString(rest[1:])
+			steps = append(steps, name)
+			rest = rest[1+len(name):]
+		case strings.HasPrefix(rest, `["`):
+			quoted, err := strconv.QuotedPrefix(rest[1:])
+			if err != nil || !strings.HasPrefix(rest[1+len(quoted):], "]") {
+				return nil, fmt.Errorf("bad key at %q", rest)
+			}
+			name, _ := strconv.Unquote(quoted)
+			steps = append(steps, name)
+			rest = rest[1+len(quoted)+1:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			i, err := strconv.Atoi(rest[1:max(end, 1)])
+			if end < 0 || err != nil || i < 0 {
+				return nil, fmt.Errorf("bad index at %q", rest)
+			}
+			steps = append(steps, i)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest)
+		}
+	}
+	return
+}
+
+// formatJSONPath formats steps as a JSON path.
+func formatJSONPath(steps []interface{}) string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, step := range steps {
+		switch s := step.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%v]", s)
+		case string:
+			if jsonPathName.FindString(s) == s {
+				b.WriteString("." + s)
+			} else {
+				fmt.Fprintf(&b, "[%q]", s)
+			}
+		}
+	}
+	return b.String()
+}
+
+// decodeJSON decodes JSON document s with numbers normalized, see JSONPath.
+func decodeJSON(s string) (v interface{}, err error) {
+	d := json.NewDecoder(bytes.NewReader([]byte(s)))
+	d.UseNumber()
+	if err = d.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid data after top-level value")
+	}
+	return normalizeJSON(v), nil
+}
+
+// normalizeJSON replaces the json.Numbers in v with ints or float64s.
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 0); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeJSON(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeJSON(e)
+		}
+	}
+	return v
+}
+
+func (c *jsonPath) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *jsonPath) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *jsonPath) Kind() string {
+	return "JSONPath"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *jsonPath) Check(v interface{}) string {
+	if s, ok := stringValue(v); ok {
+		decoded, err := decodeJSON(s)
+		if err != nil {
+			return msgf("invalid JSON <%v>: %v", s, err)
+		}
+		v = decoded
+	}
+	rv := reflect.ValueOf(v)
+	for i, step := range c.steps {
+		prefix := formatJSONPath(c.steps[:i])
+		for rv.Kind() == reflect.Interface && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		switch step := step.(type) {
+		case string:
+			if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+				return msgf("%v: <%v> is not an object", prefix, formatLeaf(rv))
+			}
+			mv := rv.MapIndex(reflect.ValueOf(step).Convert(rv.Type().Key()))
+			if !mv.IsValid() {
+				return msgf("%v: object has no key %q", prefix, step)
+			}
+			rv = mv
+		case int:
+			if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+				return msgf("%v: <%v> is not an array", prefix, formatLeaf(rv))
+			}
+			if step >= rv.Len() {
+				return msgf("%v: index %v out of range with length %v", prefix, step, rv.Len())
+			}
+			rv = rv.Index(step)
+		}
+	}
+	var value interface{}
+	if rv.IsValid() {
+		value = rv.Interface()
+	}
+	if ok, msg := cond.Check(c.c, value); !ok {
+		return msgf("%v: %v", c.path, msg)
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestAssertJSONEq(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertJSONEq(`{"a": 1, "b": [true, null, "x"], "c": {"d": 1.0}}`, []byte(`{"c":{"d":1},"b":[true,null,"x"],"a":1}`))
+	t.AssertJSONEq([]byte(" 1 "), "1")
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertJSONEq(`{"a": 2, "b": [true, "x"], "c": {"d": "1"}, "e": {}}`, `{"a": 1, "b": [true, null], "c": {"d": 1}, "f": []}`)
+	t.AssertJSONEq(`[1, 2]`, `[2, 1]`)
+	t.AssertJSONEq(`{"a": }`, `{}`)
+	t.AssertJSONEq(1, `1`)
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "JSON differs:\n"+
+			`["a"]: expected <1> but was <2>`+"\n"+
+			`["b"][1]: expected <nil> but was <"x">`+"\n"+
+			`["c"]["d"]: expected <1(float64)> but was <"1"(string)>`+"\n"+
+			`["f"]: missing`+"\n"+
+			`["e"]: unexpected <map[]>` ||
+		mock.ErrorMessages[1][0] != "JSON differs:\n[0]: expected <2> but was <1>\n[1]: expected <1> but was <2>" ||
+		mock.ErrorMessages[2][0] != "invalid JSON <{\"a\": }>: invalid character '}' looking for beginning of value" ||
+		mock.ErrorMessages[3][0] != "<1(int)> is not a string" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	defer func() {
+		if r := recover(); r != "invalid JSON <{>: unexpected end of JSON input" {
+			t1.Fatal(r)
+		}
+	}()
+	JSONEq("{")
+}
+
+func TestJSONPath(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	doc := `{"items": [{"id": 42, "price": 9.5, "tags": ["a"]}], "a b": null, "total": 1e100}`
+	t.Assert(doc, JSONPath("$.items[0].id", Equals(42)))
+	t.Assert([]byte(doc), JSONPath("$.items[0].price", Equals(9.5)))
+	t.Assert(doc, JSONPath("$.items[0]", Field("tags", EqualsSlice([]interface{}{"a"}))))
+	t.Assert(doc, JSONPath(`$["a b"]`, Equals(nil)))
+	t.Assert(doc, JSONPath("$.total", Equals(1e100)))
+	t.Assert(doc, JSONPath("$", Key("items", HasLen(1))))
+	t.Assert(map[string]interface{}{"items": []int{1}}, JSONPath("$.items[0]", Equals(1)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(doc, JSONPath("$.items[0].id", Equals(43)))
+	t.Assert(doc, JSONPath("$.items[1].id", Equals(42)))
+	t.Assert(doc, JSONPath("$.items[0].name", Equals("x")))
+	t.Assert(doc, JSONPath("$.items.id", Equals(42)))
+	t.Assert(doc, JSONPath(`$.items[0]["id"][0]`, Equals(42)))
+	t.Assert(`{"a": 1} 2`, JSONPath("$.a", Equals(1)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 6 ||
+		mock.ErrorMessages[0][0] != "$.items[0].id: expected <43> but was <42>" ||
+		mock.ErrorMessages[1][0] != "$.items: index 1 out of range with length 1" ||
+		mock.ErrorMessages[2][0] != `$.items[0]: object has no key "name"` ||
+		mock.ErrorMessages[3][0] != "$.items: <[map[id:42 price:9.5 tags:[a]]]> is not an object" ||
+		mock.ErrorMessages[4][0] != "$.items[0].id: <42> is not an array" ||
+		mock.ErrorMessages[5][0] != `invalid JSON <{"a": 1} 2>: invalid data after top-level value` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	for _, path := range []string{"items", "$.", "$[x]", `$["a`, "$[-1]", "$.a b"} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t1.Fatal(path)
+				}
+			}()
+			JSONPath(path, Equals(1))
+		}()
+	}
+}
@@ -0,0 +1,132 @@
+package asserting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// junitCase is the assertion results of a test.
+type junitCase struct {
+	name       string
+	assertions int
+	failures   []string // Failure messages with locations.
+	kinds      []string // Kinds of the failed conds.
+}
+
+// junitReport aggregates assertion results per test.
+type junitReport struct {
+	mu    sync.Mutex
+	cases []*junitCase
+	index map[string]*junitCase // Keyed by test names.
+}
+
+// add adds an assertion of test, with the failure message msg if it fails.
+func (r *junitReport) add(test string, c cond.Cond, msg string, passed bool) {
+	var failure string
+	if !passed {
+		file, line := caller()
+		failure = fmt.Sprintf("%v:%v: %v", filepath.Base(file), line, msg)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tc := r.index[test]
+	if tc == nil {
+		tc = &junitCase{name: test}
+		r.cases = append(r.cases, tc)
+		r.index[test] = tc
+	}
+	tc.assertions++
+	if !passed {
+		tc.failures = append(tc.failures, failure)
+		tc.kinds = append(tc.kinds, cond.Kind(c))
+	}
+}
+
+type junitTestSuitesXML struct {
+	XMLName xml.Name            `xml:"testsuites"`
+	Suites  []junitTestSuiteXML `xml:"testsuite"`
+}
+
+type junitTestSuiteXML struct {
+	Name       string             `xml:"name,attr"`
+	Tests      int                `xml:"tests,attr"`
+	Failures   int                `xml:"failures,attr"`
+	Assertions int                `xml:"assertions,attr"`
+	Cases      []junitTestCaseXML `xml:"testcase"`
+}
+
+type junitTestCaseXML struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Assertions int              `xml:"assertions,attr"`
+	Failure    *junitFailureXML `xml:"failure,omitempty"`
+}
+
+type junitFailureXML struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// write writes the report of the test suite named suite to path.
+func (r *junitReport) write(path, suite string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := junitTestSuiteXML{Name: suite, Tests: len(r.cases)}
+	for _, tc := range r.cases {
+		x := junitTestCaseXML{Name: tc.name, ClassName: suite, Assertions: tc.assertions}
+		if len(tc.failures) > 0 {
+			x.Failure = &junitFailureXML{
+				Message: tc.failures[0],
+				Type:    tc.kinds[0],
+				Text:    strings.Join(tc.failures, "\n"),
+			}
+			s.Failures++
+		}
+		s.Assertions += tc.assertions
+		s.Cases = append(s.Cases, x)
+	}
+	b, err := xml.MarshalIndent(junitTestSuitesXML{Suites: []junitTestSuiteXML{s}}, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), append(b, '\n')...), 0o666)
+}
+
+var junit atomic.Pointer[junitReport]
+
+// RunJUnit runs the tests with m.Run, aggregating the results of the assertions made
+// with TB.Assert, including the methods calling it, per test, and then writes them to
+// the file at path as a JUnit XML report, including failure messages and locations.
+// Tests making no assertions are not reported. The test suite is named after the test
+// binary. RunJUnit returns the result of m.Run, or 1 if the report can't be written.
+// RunJUnit is meant to be called in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(asserting.RunJUnit(m, "junit.xml"))
+//	}
+func RunJUnit(m *testing.M, path string) int {
+	return runJUnit(m.Run, path, strings.TrimSuffix(filepath.Base(os.Args[0]), ".test"))
+}
+
+// runJUnit calls run, and writes the JUnit report of the test suite named suite
+// to path. See RunJUnit.
+func runJUnit(run func() int, path, suite string) int {
+	r := &junitReport{index: make(map[string]*junitCase)}
+	prev := junit.Swap(r)
+	code := run()
+	junit.Store(prev)
+	if err := r.write(path, suite); err != nil {
+		fmt.Fprintln(os.Stderr, "can't write JUnit report:", err)
+		return 1
+	}
+	return code
+}
@@ -0,0 +1,53 @@
+package asserting_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestRunJUnit(t1 *testing.T) {
+	path := filepath.Join(t1.TempDir(), "junit.xml")
+	var line int
+	code := RunJUnitFunc(func() int {
+		t1.Run("pass", func(t2 *testing.T) {
+			t := NewTB(t2)
+			t.AssertEqual(1, 1)
+			t.AssertEqual(2, 2)
+		})
+		t1.Run("fail", func(t2 *testing.T) {
+			t := NewTB(mocktb.New(t2))
+			t.AssertEqual(1, 1)
+			_, _, line, _ = runtime.Caller(0)
+			t.AssertEqual(1, 2)
+			t.Assert(3, IsNil())
+		})
+		return 7
+	}, path, "suite")
+	if code != 7 {
+		t1.Fatal(code)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t1.Fatal(err)
+	}
+	NewTB(t1).AssertEqual(string(content), fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+	<testsuite name="suite" tests="2" failures="1" assertions="5">
+		<testcase name="TestRunJUnit/pass" classname="suite" assertions="2"></testcase>
+		<testcase name="TestRunJUnit/fail" classname="suite" assertions="3">
+			<failure message="junit_test.go:%[1]v: expected &lt;2&gt; but was &lt;1&gt;" type="Equals">junit_test.go:%[1]v: expected &lt;2&gt; but was &lt;1&gt;&#xA;junit_test.go:%[2]v: expected nil but was &lt;3(int)&gt;</failure>
+		</testcase>
+	</testsuite>
+</testsuites>
+`, line+1, line+2))
+
+	if code := RunJUnitFunc(func() int { return 0 }, filepath.Join(path, "x"), "suite"); code != 1 {
+		t1.Fatal(code)
+	}
+}
@@ -0,0 +1,113 @@
+package asserting
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type hasLen struct {
+	n interface{} // An int or a cond.Cond.
+}
+
+// HasLen returns a cond which is true if the length of the tested string, slice,
+// array, map, channel, or pointer to array, is n, where n is either an int, or a
+// cond.Cond which the length must meet, e.g. HasLen(LessThan(10)).
+// HasLen panics if n is neither.
+func HasLen(n interface{}) cond.Cond {
+	switch n.(type) {
+	case int, cond.Cond:
+	default:
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is neither an int nor a cond.Cond", n))
+	}
+	return cond.New(&hasLen{n: n})
+}
+
+func (c *hasLen) Test(v interface{}) bool {
+	l, ok := length(v)
+	if !ok {
+		return false
+	}
+	if lc, ok := c.n.(cond.Cond); ok {
+		return lc.Test(l)
+	}
+	return l == c.n
+}
+
+func (c *hasLen) Message(v interface{}) string {
+	l, ok := length(v)
+	if !ok {
+		return msgf("<%[1]v(%[1]T)> has no length", v)
+	}
+	if lc, ok := c.n.(cond.Cond); ok {
+		return msgf("length %v of <%v>: %v", l, formatValue(v), cond.Message(lc, l))
+	}
+	return msgf("expected length %v but was %v <%v>", c.n, l, formatValue(v))
+}
+
+func (c *hasLen) Kind() string {
+	return "HasLen"
+}
+
+// length returns the length of v. ok is false if v has no length.
+func length(v interface{}) (n int, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return rv.Len(), true
+	case reflect.Ptr:
+		if rv.Type().Elem().Kind() == reflect.Array {
+			return rv.Len(), true
+		}
+	}
+	return 0, false
+}
+
+type isEmpty struct {
+	empty bool // Whether the tested value is expected to be empty.
+}
+
+// IsEmpty returns a cond which is true if the tested value is empty, i.e. nil,
+// a string, slice, array, map or channel of length 0, or a nil pointer.
+func IsEmpty() cond.Cond {
+	return cond.New(&isEmpty{empty: true})
+}
+
+// NotEmpty returns a cond which is true if the tested value is not empty, see IsEmpty.
+func NotEmpty() cond.Cond {
+	return cond.New(&isEmpty{empty: false})
+}
+
+func (c *isEmpty) Test(v interface{}) bool {
+	return empty(v) == c.empty
+}
+
+func (c *isEmpty) Message(v interface{}) string {
+	if c.empty {
+		return msgf("expected empty but was <%v>", formatValue(v))
+	}
+	return msgf("unexpected empty <%v(%T)>", formatValue(v), v)
+}
+
+func (c *isEmpty) Kind() string {
+	if c.empty {
+		return "IsEmpty"
+	}
+	return "NotEmpty"
+}
+
+// empty returns whether v is empty, see IsEmpty.
+func empty(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr:
+		return rv.IsNil()
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return rv.Len() == 0
+	default:
+		return false
+	}
+}
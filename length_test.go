@@ -0,0 +1,80 @@
+package asserting_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestHasLen(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	ch := make(chan int, 2)
+	ch <- 1
+	t.Assert("abc", HasLen(3))
+	t.Assert([]int{1, 2}, HasLen(2))
+	t.Assert([3]int{}, HasLen(3))
+	t.Assert(&[3]int{}, HasLen(3))
+	t.Assert(map[int]int{1: 1}, HasLen(1))
+	t.Assert(ch, HasLen(1))
+	t.Assert([]int(nil), HasLen(0))
+	t.Assert("abc", HasLen(LessThan(4)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{1, 2, 3, 4, 5}, HasLen(3))
+	t.Assert(make([]int, 20), HasLen(3))
+	t.Assert("abcd", HasLen(LessThan(4)))
+	t.Assert(1, HasLen(1))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "expected length 3 but was 5 <[1 2 3 4 5]>" ||
+		mock.ErrorMessages[1][0] != "expected length 3 but was 20 <"+fmt.Sprint(make([]int, 20))+">" ||
+		mock.ErrorMessages[2][0] != `length 4 of <"abcd">: <4> is not less than <4>` ||
+		mock.ErrorMessages[3][0] != "<1(int)> has no length" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(func() { HasLen("3") }, Panics("<3(string)> is neither an int nor a cond.Cond"))
+	if len(mock.ErrorMessages) != 4 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestIsEmpty(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert("", IsEmpty())
+	t.Assert([]int{}, IsEmpty())
+	t.Assert(map[int]int(nil), IsEmpty())
+	t.Assert(make(chan int), IsEmpty())
+	t.Assert((*int)(nil), IsEmpty())
+	t.Assert(nil, IsEmpty())
+	t.Assert("a", NotEmpty())
+	t.Assert(new(int), NotEmpty())
+	t.Assert(0, NotEmpty())
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{1}, IsEmpty())
+	t.Assert(" ", IsEmpty())
+	t.Assert("", NotEmpty())
+	t.Assert([]int(nil), NotEmpty())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "expected empty but was <[1]>" ||
+		mock.ErrorMessages[1][0] != `expected empty but was <" ">` ||
+		mock.ErrorMessages[2][0] != `unexpected empty <""(string)>` ||
+		mock.ErrorMessages[3][0] != "unexpected empty <[]([]int)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,62 @@
+package asserting
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// limitTB is a testing.TB stopping the test after too many errors.
+type limitTB struct {
+	testing.TB
+	max    int
+	mu     sync.Mutex
+	errors int
+}
+
+func (l *limitTB) unwrap() testing.TB {
+	return l.TB
+}
+
+// exceeded counts an error and returns whether the error exceeds the limit.
+func (l *limitTB) exceeded() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors++
+	return l.errors > l.max
+}
+
+func (l *limitTB) Error(args ...interface{}) {
+	l.TB.Helper()
+	if l.exceeded() {
+		l.abort()
+		return
+	}
+	l.TB.Error(args...)
+}
+
+func (l *limitTB) Errorf(format string, args ...interface{}) {
+	l.TB.Helper()
+	if l.exceeded() {
+		l.abort()
+		return
+	}
+	l.TB.Errorf(format, args...)
+}
+
+func (l *limitTB) abort() {
+	l.TB.Helper()
+	l.TB.Fatal(msgf("too many errors, aborted after %v", l.max))
+}
+
+// WithMaxErrors returns a TB which reports failures as t does, except that once max
+// errors have been reported through it, the next error aborts the test with Fatal
+// instead of being reported, so that a broken loop asserting thousands of elements
+// doesn't flood the log. Failures reported with Fatal are not counted.
+// WithMaxErrors panics if max is negative.
+func (t TB) WithMaxErrors(max int) TB {
+	if max < 0 {
+		panic(fmt.Sprintf("negative max errors %v", max))
+	}
+	return TB{&limitTB{TB: t.TB, max: max}}
+}
@@ -0,0 +1,25 @@
+package asserting_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestWithMaxErrors(t1 *testing.T) {
+	mock := mocktb.New(t1)
+	t := NewTB(mock).WithMaxErrors(2)
+
+	for i := 0; i < 5; i++ {
+		t.AssertEqual(i, -1)
+		if len(mock.Fatals()) != 0 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(mock.Errors(), []string{"expected <-1> but was <0>", "expected <-1> but was <1>"}) ||
+		!reflect.DeepEqual(mock.Fatals(), []string{"too many errors, aborted after 2"}) {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+}
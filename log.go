@@ -0,0 +1,114 @@
+package asserting
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type noLogsAbove struct {
+	level slog.Leveler
+	msg   string // The failure message of the last Test.
+}
+
+// NoLogsAbove returns a cond which is true if calling the tested function logs
+// nothing at or above level through the default slog.Logger, or the log package.
+// A capturing handler is installed as the default for the duration of the call,
+// records below level are discarded.
+// The cond is false if the tested value is not of type func().
+//
+// Because the default logger is process-wide, NoLogsAbove must not be used in parallel
+// tests: logs of other goroutines during the call are captured too, and the cond is
+// false if it is tested while another NoLogsAbove is being tested.
+func NoLogsAbove(level slog.Leveler) cond.Cond {
+	return cond.New(&noLogsAbove{level: level})
+}
+
+func (c *noLogsAbove) Test(v interface{}) bool {
+	f, ok := v.(func())
+	if !ok {
+		c.msg = notFuncMsg(v)
+		return false
+	}
+	logs, ok := captureLogs(c.level, f)
+	if !ok {
+		c.msg = msgf("NoLogsAbove is tested concurrently, e.g. in parallel tests, which is not supported")
+		return false
+	}
+	if logs == "" {
+		c.msg = ""
+		return true
+	}
+	c.msg = msgf("unexpected logs at or above %v:\n%v", c.level.Level(), logs)
+	return false
+}
+
+func (c *noLogsAbove) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *noLogsAbove) Kind() string {
+	return "NoLogsAbove"
+}
+
+// AssertNoLogsAbove calls t.Assert(f, NoLogsAbove(level)).
+func (t TB) AssertNoLogsAbove(level slog.Leveler, f func()) {
+	t.Helper()
+	t.Assert(f, NoLogsAbove(level))
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// capturing is whether captureLogs is running.
+var capturing atomic.Bool
+
+// captureLogs calls f with the default slog.Logger replaced, and returns the records
+// at or above level logged during the call, in text format without time.
+// ok is false, and f is not called, if another captureLogs is running.
+func captureLogs(level slog.Leveler, f func()) (logs string, ok bool) {
+	if !capturing.CompareAndSwap(false, true) {
+		return "", false
+	}
+	defer capturing.Store(false)
+	var buf lockedBuffer
+	h := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	// slog.SetDefault redirects the log package, which is not restored by
+	// setting the previous logger back.
+	prev, out, flags := slog.Default(), log.Writer(), log.Flags()
+	defer func() {
+		slog.SetDefault(prev)
+		log.SetOutput(out)
+		log.SetFlags(flags)
+	}()
+	slog.SetDefault(slog.New(h))
+	f()
+	return buf.String(), true
+}
@@ -0,0 +1,56 @@
+package asserting_test
+
+import (
+	"log"
+	"log/slog"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestAssertNoLogsAbove(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	prev := slog.Default()
+	t.AssertNoLogsAbove(slog.LevelWarn, func() {})
+	t.AssertNoLogsAbove(slog.LevelWarn, func() { slog.Info("starting", "port", 80) })
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertNoLogsAbove(slog.LevelWarn, func() {
+		slog.Debug("retrying")
+		slog.With("id", 1).Warn("slow", "ms", 1200)
+		slog.Error("swallowed", "err", "EOF")
+		log.Print("plain")
+	})
+	t.Assert(1, NoLogsAbove(slog.LevelWarn))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != `unexpected logs at or above WARN:
+level=WARN msg=slow id=1 ms=1200
+level=ERROR msg=swallowed err=EOF
+` ||
+		mock.ErrorMessages[1][0] != "<1(int)> is not a func()" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+	if slog.Default() != prev {
+		t1.Fatal("default logger not restored")
+	}
+}
+
+func TestNoLogsAboveConcurrently(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertNoLogsAbove(slog.LevelWarn, func() {
+		t.AssertNoLogsAbove(slog.LevelWarn, func() {})
+	})
+	if len(mock.ErrorMessages) != 1 ||
+		mock.ErrorMessages[0][0] != "NoLogsAbove is tested concurrently, e.g. in parallel tests, which is not supported" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
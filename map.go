@@ -0,0 +1,110 @@
+package asserting
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type equalsMap struct {
+	expected interface{}
+}
+
+// EqualsMap returns a cond which is true if the tested map has the same keys as the
+// expected map, and the values of every key are equal, as reflect.DeepEqual reports.
+// nil equals to empty map. The maps are iterated directly, and the comparison stops at
+// the first mismatching key. The failure message lists every mismatching key, e.g.
+// `["a"]: expected <1> but was <2>`.
+// The cond is false if the tested value is not a map of the type of expected.
+func EqualsMap(expected interface{}) cond.Cond {
+	return cond.New(&equalsMap{expected: expected})
+}
+
+func (c *equalsMap) Test(v interface{}) bool {
+	if v == nil && c.expected == nil {
+		return true
+	}
+	x, y, ok := maps(c.expected, v)
+	if !ok {
+		return false
+	}
+	if x.Len() != y.Len() {
+		return false
+	}
+	for iter := x.MapRange(); iter.Next(); {
+		value := y.MapIndex(iter.Key())
+		if !value.IsValid() || !reflect.DeepEqual(iter.Value().Interface(), value.Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *equalsMap) Message(v interface{}) string {
+	x, y, ok := maps(c.expected, v)
+	if !ok {
+		if reflect.ValueOf(v).Kind() != reflect.Map && v != nil {
+			return msgf("<%[1]v(%[1]T)> is not a map", v)
+		}
+		return formatMsg("expected <%v> but was <%v>", c.expected, v)
+	}
+	var diffs []string
+	report := func(key reflect.Value, format string, args ...interface{}) {
+		diffs = append(diffs, "["+formatLeaf(key)+"]: "+msgf(format, args...))
+	}
+	for _, key := range sortedKeys(x) {
+		if value := y.MapIndex(key); !value.IsValid() {
+			report(key, "missing")
+		} else if expected := x.MapIndex(key); !reflect.DeepEqual(expected.Interface(), value.Interface()) {
+			report(key, "expected <%v> but was <%v>", formatLeaf(expected), formatLeaf(value))
+		}
+	}
+	for _, key := range sortedKeys(y) {
+		if !x.MapIndex(key).IsValid() {
+			report(key, "unexpected <%v>", formatLeaf(y.MapIndex(key)))
+		}
+	}
+	if len(diffs) > maxDiffs {
+		diffs = append(diffs[:maxDiffs], "...(more differences)")
+	}
+	return msgf("map mismatch:\n%v", strings.Join(diffs, "\n"))
+}
+
+func (c *equalsMap) Kind() string {
+	return "EqualsMap"
+}
+
+func (c *equalsMap) Expected() interface{} {
+	return c.expected
+}
+
+// maps returns a and b as maps of the same type. A nil interface value is an empty
+// map of the type of the other. ok is false if a or b is not a map, or they are of
+// different types.
+func maps(a, b interface{}) (x, y reflect.Value, ok bool) {
+	x, y = reflect.ValueOf(a), reflect.ValueOf(b)
+	if !x.IsValid() && y.IsValid() {
+		x = reflect.Zero(y.Type())
+	} else if !y.IsValid() && x.IsValid() {
+		y = reflect.Zero(x.Type())
+	}
+	if !x.IsValid() {
+		return x, y, false
+	}
+	return x, y, x.Kind() == reflect.Map && x.Type() == y.Type()
+}
+
+// sortedKeys returns the keys of map m, sorted by their formatted values.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return formatLeaf(keys[i]) < formatLeaf(keys[j]) })
+	return keys
+}
+
+// AssertEqualMap calls t.Assert(v, EqualsMap(expected)).
+func (t TB) AssertEqualMap(v, expected interface{}) {
+	t.Helper()
+	t.Assert(v, EqualsMap(expected))
+}
@@ -0,0 +1,36 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestEqualsMap(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(map[string][]int{"a": {1}, "b": nil}, EqualsMap(map[string][]int{"b": nil, "a": {1}}))
+	t.AssertEqualMap(map[string]int{}, map[string]int(nil))
+	t.Assert(nil, EqualsMap(map[string]int{}))
+	t.Assert(nil, EqualsMap(nil))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(map[string]int{"a": 2, "c": 3}, EqualsMap(map[string]int{"a": 1, "b": 2}))
+	t.Assert(map[string]int{"a": 1}, EqualsMap(map[string]int8{"a": 1}))
+	t.Assert(1, EqualsMap(map[string]int{}))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != `map mismatch:
+["a"]: expected <1> but was <2>
+["b"]: missing
+["c"]: unexpected <3>` ||
+		mock.ErrorMessages[1][0] != "expected <map[a:1](map[string]int8)> but was <map[a:1](map[string]int)>" ||
+		mock.ErrorMessages[2][0] != "<1(int)> is not a map" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
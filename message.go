@@ -0,0 +1,44 @@
+package asserting
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Catalog maps the templates of failure messages to the templates replacing them,
+// e.g. translations. The keys are the fmt format strings used by the conditions of
+// this package, such as "expected <%v> but was <%v>" and "unexpected error <%v>".
+// A replacement must consume the same arguments, in the same order unless explicit
+// argument indexes, such as %[2]v, are used.
+// Templates absent from the catalog are used as is.
+type Catalog map[string]string
+
+type catalogHolder struct {
+	c Catalog
+}
+
+var catalog atomic.Value // catalogHolder
+
+// SetCatalog replaces the message templates with c, and returns the catalog previously
+// set. A nil catalog restores the default templates.
+// c must not be modified after the call.
+func SetCatalog(c Catalog) Catalog {
+	prev, _ := catalog.Swap(catalogHolder{c}).(catalogHolder)
+	return prev.c
+}
+
+// localize returns the replacement of template in the current catalog, or template
+// itself if there is none.
+func localize(template string) string {
+	if h, ok := catalog.Load().(catalogHolder); ok {
+		if r, ok := h.c[template]; ok {
+			return r
+		}
+	}
+	return template
+}
+
+// msgf formats a failure message with the replacement of template, see Catalog.
+func msgf(template string, args ...interface{}) string {
+	return fmt.Sprintf(localize(template), args...)
+}
@@ -0,0 +1,49 @@
+package asserting_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestSetCatalog(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	prev := SetCatalog(Catalog{
+		"expected <%v> but was <%v>":             "attendu <%v> mais était <%v>",
+		"unexpected error <%v>":                  "erreur inattendue <%v>",
+		"unexpected <%v>":                        "<%v> inattendu",
+		"sync.Map mismatch:\n%v":                 "sync.Map différent :\n%v",
+		"expected nil slice but was empty slice": "attendu slice nil mais était slice vide",
+	})
+	t.AssertEqual(1, 2)
+	t.AssertNoError(errors.New("EOF"))
+	t.AssertNotEqual(1, 1)
+	t.Assert(1, IsKind(reflect.Uint8))
+	var m sync.Map
+	m.Store("a", 1)
+	t.Assert(&m, SyncMapEquals(nil))
+	t.Assert([]int{}, EqualsSliceStrictly(nil))
+	if SetCatalog(prev) == nil || SetCatalog(nil) != nil {
+		t1.Fatal()
+	}
+	t.AssertEqual(1, 2)
+
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 7 ||
+		mock.ErrorMessages[0][0] != "attendu <2> mais était <1>" ||
+		mock.ErrorMessages[1][0] != "erreur inattendue <EOF>" ||
+		mock.ErrorMessages[2][0] != "<1> inattendu" ||
+		mock.ErrorMessages[3][0] != "expected kind <uint8> but was <int> <1(int)>" ||
+		mock.ErrorMessages[4][0] != "sync.Map différent :\n[\"a\"]: <1> inattendu" ||
+		mock.ErrorMessages[5][0] != "attendu slice nil mais était slice vide" ||
+		mock.ErrorMessages[6][0] != "expected <2> but was <1>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,141 @@
+// Package mocktb provides a testing.TB which records failures instead of reporting
+// them, for testing helpers built on top of testing.TB, such as asserting conditions.
+package mocktb
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TB is a testing.TB recording the messages of Error, Fatal, Log and their
+// formatting variants, and the failure state. Other methods, such as Name, Cleanup
+// and Helper, are those of the underlying testing.TB.
+//
+// Unlike testing.T, FailNow, and thus Fatal, SkipNow and their variants don't stop the
+// calling goroutine, so the code under test keeps running. To emulate a stopped test,
+// everything reported after the first FailNow or SkipNow is ignored.
+// TB is safe for concurrent use.
+type TB struct {
+	testing.TB
+
+	mu      sync.Mutex
+	errors  []string
+	fatals  []string
+	logs    []string
+	failed  bool
+	stopped bool
+	skipped bool
+}
+
+// New returns a TB using t as the underlying testing.TB.
+func New(t testing.TB) *TB {
+	return &TB{TB: t}
+}
+
+// record appends msg to *list and marks the test failed and/or stopped,
+// unless the test has been stopped.
+func (m *TB) record(list *[]string, msg string, fail, stop bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped {
+		return
+	}
+	if list != nil {
+		*list = append(*list, msg)
+	}
+	m.failed = m.failed || fail
+	m.stopped = stop
+}
+
+func (m *TB) Error(args ...interface{}) {
+	m.record(&m.errors, fmt.Sprint(args...), true, false)
+}
+
+func (m *TB) Errorf(format string, args ...interface{}) {
+	m.record(&m.errors, fmt.Sprintf(format, args...), true, false)
+}
+
+func (m *TB) Fatal(args ...interface{}) {
+	m.record(&m.fatals, fmt.Sprint(args...), true, true)
+}
+
+func (m *TB) Fatalf(format string, args ...interface{}) {
+	m.record(&m.fatals, fmt.Sprintf(format, args...), true, true)
+}
+
+func (m *TB) Log(args ...interface{}) {
+	m.record(&m.logs, fmt.Sprint(args...), false, false)
+}
+
+func (m *TB) Logf(format string, args ...interface{}) {
+	m.record(&m.logs, fmt.Sprintf(format, args...), false, false)
+}
+
+func (m *TB) Fail() {
+	m.record(nil, "", true, false)
+}
+
+func (m *TB) FailNow() {
+	m.record(nil, "", true, true)
+}
+
+func (m *TB) Failed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.failed
+}
+
+func (m *TB) Skip(args ...interface{}) {
+	m.Log(args...)
+	m.SkipNow()
+}
+
+func (m *TB) Skipf(format string, args ...interface{}) {
+	m.Logf(format, args...)
+	m.SkipNow()
+}
+
+func (m *TB) SkipNow() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.stopped {
+		m.skipped = true
+		m.stopped = true
+	}
+}
+
+func (m *TB) Skipped() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.skipped
+}
+
+// Errors returns the messages reported with Error and Errorf.
+func (m *TB) Errors() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.errors...)
+}
+
+// Fatals returns the messages reported with Fatal and Fatalf.
+func (m *TB) Fatals() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.fatals...)
+}
+
+// Logs returns the messages logged with Log, Logf, Skip and Skipf.
+func (m *TB) Logs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.logs...)
+}
+
+// Reset clears all the recorded messages and states.
+func (m *TB) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors, m.fatals, m.logs = nil, nil, nil
+	m.failed, m.stopped, m.skipped = false, false, false
+}
@@ -0,0 +1,42 @@
+package mocktb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestTB(t *testing.T) {
+	m := mocktb.New(t)
+	a := asserting.NewTB(m)
+
+	a.AssertEqual(1, 1)
+	m.Log("a", 1)
+	if m.Failed() || len(m.Errors()) != 0 || !reflect.DeepEqual(m.Logs(), []string{"a1"}) {
+		t.Fatal(m.Errors(), m.Logs())
+	}
+
+	a.AssertEqual(1, 2)
+	m.Errorf("%v-%v", 1, 2)
+	if !m.Failed() || len(m.Fatals()) != 0 ||
+		!reflect.DeepEqual(m.Errors(), []string{"expected <2> but was <1>", "1-2"}) {
+		t.Fatal(m.Errors())
+	}
+
+	a.Assert(1, asserting.Equals(3).SetFatal())
+	a.AssertEqual(1, 4)
+	m.Log("ignored")
+	if !reflect.DeepEqual(m.Fatals(), []string{"expected <3> but was <1>"}) ||
+		len(m.Errors()) != 2 || len(m.Logs()) != 1 {
+		t.Fatal(m.Fatals(), m.Errors(), m.Logs())
+	}
+
+	m.Reset()
+	m.Skip("skipped")
+	m.Error("ignored")
+	if m.Failed() || !m.Skipped() || len(m.Errors()) != 0 || !reflect.DeepEqual(m.Logs(), []string{"skipped"}) {
+		t.Fatal(m.Errors(), m.Logs())
+	}
+}
@@ -0,0 +1,152 @@
+package asserting
+
+import (
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type doesNotMutate struct {
+	input interface{}
+	msg   string // The failure message of the last Test.
+}
+
+// DoesNotMutate returns a cond which is true if calling the tested function does not
+// modify input, or anything reachable from input through pointers, slices, maps and
+// interfaces. The input is deep-copied before the call, and compared with the copy
+// afterwards. The failure message lists the path of every modified value.
+// The cond is false if the tested value is not of type func().
+func DoesNotMutate(input interface{}) cond.Cond {
+	return cond.New(&doesNotMutate{input: input})
+}
+
+func (c *doesNotMutate) Test(v interface{}) bool {
+	f, ok := v.(func())
+	if !ok {
+		c.msg = notFuncMsg(v)
+		return false
+	}
+	before := deepCopy(c.input)
+	f()
+	c.msg = mutationDiff(before, c.input)
+	return c.msg == ""
+}
+
+func (c *doesNotMutate) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *doesNotMutate) Kind() string {
+	return "DoesNotMutate"
+}
+
+// AssertDoesNotMutate calls t.Assert(f, DoesNotMutate(input)).
+func (t TB) AssertDoesNotMutate(input interface{}, f func()) {
+	t.Helper()
+	t.Assert(f, DoesNotMutate(input))
+}
+
+// mutationDiff returns the differences between before and after, or an empty string
+// if there is none.
+func mutationDiff(before, after interface{}) string {
+	x, y := reflect.ValueOf(before), reflect.ValueOf(after)
+	var path string
+	if x.IsValid() {
+		path = x.Type().String()
+	}
+	d := differ{exact: true, funcPointers: true}
+	d.walk(path, x, y, 0)
+	if len(d.diffs) == 0 {
+		return ""
+	}
+	if len(d.diffs) > maxDiffs {
+		d.diffs = append(d.diffs[:maxDiffs], "...(more differences)")
+	}
+	return msgf("input mutated:\n%v", strings.Join(d.diffs, "\n"))
+}
+
+// deepCopy returns a deep copy of v, including unexported fields.
+func deepCopy(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil
+	}
+	return copyValue(addressable(rv), map[copyKey]reflect.Value{}).Interface()
+}
+
+// addressable returns an addressable copy of v.
+func addressable(v reflect.Value) reflect.Value {
+	a := reflect.New(v.Type()).Elem()
+	a.Set(v)
+	return a
+}
+
+// copyKey identifies a copied pointer. The type is part of the key because
+// pointers of different types may share an address, e.g. a pointer to a struct
+// and a pointer to its first field.
+type copyKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// copyValue returns an addressable deep copy of v, which must be addressable.
+// seen maps the copied pointers to their copies.
+func copyValue(v reflect.Value, seen map[copyKey]reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+		k := copyKey{v.Pointer(), v.Type()}
+		if p, ok := seen[k]; ok {
+			c.Set(p)
+			break
+		}
+		p := reflect.New(v.Type().Elem())
+		seen[k] = p
+		p.Elem().Set(copyValue(v.Elem(), seen))
+		c.Set(p)
+	case reflect.Interface:
+		if v.IsNil() {
+			break
+		}
+		c.Set(copyValue(addressable(v.Elem()), seen))
+	case reflect.Slice:
+		if v.IsNil() {
+			break
+		}
+		c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyValue(v.Index(i), seen))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyValue(v.Index(i), seen))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			break
+		}
+		c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(copyValue(addressable(iter.Key()), seen), copyValue(addressable(iter.Value()), seen))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writable(c.Field(i)).Set(copyValue(writable(v.Field(i)), seen))
+		}
+	default:
+		c.Set(writable(v))
+	}
+	return c
+}
+
+// writable returns v, which must be addressable, without the read-only flag of values
+// obtained through unexported fields.
+func writable(v reflect.Value) reflect.Value {
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
@@ -0,0 +1,84 @@
+package asserting_test
+
+import (
+	"sort"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+type node struct {
+	value    int
+	children []*node
+	parent   *node
+	attrs    map[string]interface{}
+}
+
+func TestAssertDoesNotMutate(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	root := &node{value: 1, attrs: map[string]interface{}{"a": []int{1}}}
+	child := &node{value: 2, parent: root}
+	root.children = []*node{child}
+	s := []int{3, 1, 2}
+
+	t.AssertDoesNotMutate(root, func() { _ = root.children[0].parent.value })
+	t.AssertDoesNotMutate(s, func() { sort.Ints(append([]int(nil), s...)) })
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertDoesNotMutate(s, func() { sort.Ints(s) })
+	t.AssertDoesNotMutate(root, func() {
+		root.children[0].value = 3
+		root.attrs["a"].([]int)[0] = 2
+		delete(root.attrs, "a")
+	})
+	t.AssertDoesNotMutate(root, func() { root.attrs["b"] = nil })
+	t.Assert(1, DoesNotMutate(root))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != `input mutated:
+[]int[0]: expected <3> but was <1>
+[]int[1]: expected <1> but was <2>
+[]int[2]: expected <2> but was <3>` ||
+		mock.ErrorMessages[1][0] != `input mutated:
+*asserting_test.node.children[0].value: expected <2> but was <3>
+*asserting_test.node.attrs["a"]: missing` ||
+		mock.ErrorMessages[2][0] != `input mutated:
+*asserting_test.node.attrs["b"]: unexpected <<nil>>` ||
+		mock.ErrorMessages[3][0] != "<1(int)> is not a func()" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type pointee struct {
+	X int
+}
+
+type aliases struct {
+	P *pointee
+	Q *int // Shares the address of P.
+}
+
+func TestAssertDoesNotMutateAliases(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	p := &pointee{}
+	s := aliases{P: p, Q: &p.X}
+	t.AssertDoesNotMutate(s, func() {})
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertDoesNotMutate(s, func() { *s.Q = 1 })
+	if len(mock.ErrorMessages) != 1 || mock.ErrorMessages[0][0] != `input mutated:
+asserting_test.aliases.P.X: expected <0> but was <1>
+asserting_test.aliases.Q: expected <0> but was <1>` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,297 @@
+package asserting
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type numeric struct {
+	v interface{}
+}
+
+// Numeric returns a value which is reported by Assert equal to values of any
+// integer, float or complex type, including named types such as time.Duration,
+// if they have the same mathematical value.
+// Numeric panics if v is not of integer, float or complex kind.
+func Numeric(v interface{}) interface{} {
+	if !isNumber(reflect.ValueOf(v)) {
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a number", v))
+	}
+	return numeric{v}
+}
+
+func (n numeric) equals(r interface{}) bool {
+	if m, ok := r.(numeric); ok {
+		r = m.v
+	}
+	eq, ok := numberEquals(reflect.ValueOf(n.v), reflect.ValueOf(r))
+	return ok && eq
+}
+
+func (n numeric) String() string {
+	return formatValue(n.v)
+}
+
+// isNumber returns whether v is of integer, float or complex kind.
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRealNumber returns whether v is of integer or float kind.
+func isRealNumber(v reflect.Value) bool {
+	return isNumber(v) && v.Kind() != reflect.Complex64 && v.Kind() != reflect.Complex128
+}
+
+// numberEquals tests whether x and y have the same mathematical value.
+// ok is false if x or y is not a number.
+func numberEquals(x, y reflect.Value) (eq bool, ok bool) {
+	if !isNumber(x) || !isNumber(y) {
+		return false, false
+	}
+	if x.Kind() == reflect.Complex64 || x.Kind() == reflect.Complex128 {
+		x, y = y, x
+	}
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intEquals(x.Int(), y), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintEquals(x.Uint(), y), true
+	case reflect.Float32, reflect.Float64:
+		return floatEquals(x.Float(), y), true
+	default: // Both complex.
+		return x.Complex() == y.Complex(), true
+	}
+}
+
+// intEquals tests whether number y equals to i.
+func intEquals(i int64, y reflect.Value) bool {
+	switch y.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return i == y.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return i >= 0 && uint64(i) == y.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := y.Float()
+		// Converting f to int64 is exact if f is an integer in range.
+		return f == math.Trunc(f) && f >= -(1<<63) && f < 1<<63 && int64(f) == i
+	default: // Complex.
+		c := y.Complex()
+		return imag(c) == 0 && intEquals(i, reflect.ValueOf(real(c)))
+	}
+}
+
+// uintEquals tests whether number y equals to u.
+func uintEquals(u uint64, y reflect.Value) bool {
+	switch y.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intEquals(y.Int(), reflect.ValueOf(u))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return u == y.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := y.Float()
+		// Converting f to uint64 is exact if f is an integer in range.
+		return f == math.Trunc(f) && f >= 0 && f < 1<<64 && uint64(f) == u
+	default: // Complex.
+		c := y.Complex()
+		return imag(c) == 0 && uintEquals(u, reflect.ValueOf(real(c)))
+	}
+}
+
+// floatEquals tests whether number y equals to f.
+func floatEquals(f float64, y reflect.Value) bool {
+	switch y.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intEquals(y.Int(), reflect.ValueOf(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintEquals(y.Uint(), reflect.ValueOf(f))
+	case reflect.Float32, reflect.Float64:
+		return f == y.Float()
+	default: // Complex.
+		c := y.Complex()
+		return imag(c) == 0 && f == real(c)
+	}
+}
+
+// FloatMode controls how EqualsFloat treats special float values.
+// The zero FloatMode follows IEEE 754: +0.0 equals -0.0, an infinity equals
+// the infinity of the same sign and NaN equals nothing.
+type FloatMode int
+
+const (
+	// SignedZero makes +0.0 and -0.0 unequal.
+	SignedZero FloatMode = 1 << iota
+	// NaNEqual makes NaN equal to NaN.
+	NaNEqual
+	// NoInf makes infinities unequal to any value, including themselves.
+	NoInf
+)
+
+type equalsFloat struct {
+	expected interface{}
+	mode     FloatMode
+}
+
+// EqualsFloat returns a cond which is true if a number has the same mathematical value
+// as the expected number, as if expected were wrapped with Numeric, with
+// special float values treated according to mode.
+// EqualsFloat panics if expected is not of integer or float kind.
+func EqualsFloat(expected interface{}, mode FloatMode) cond.Cond {
+	if !isRealNumber(reflect.ValueOf(expected)) {
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a real number", expected))
+	}
+	return cond.New(&equalsFloat{expected: expected, mode: mode})
+}
+
+func (c *equalsFloat) Test(v interface{}) bool {
+	x, y := reflect.ValueOf(c.expected), reflect.ValueOf(v)
+	if !isRealNumber(y) {
+		return false
+	}
+	if isFloat(x) && isFloat(y) {
+		fx, fy := x.Float(), y.Float()
+		if c.mode&NaNEqual != 0 && math.IsNaN(fx) && math.IsNaN(fy) {
+			return true
+		}
+		if c.mode&SignedZero != 0 && fx == 0 && fy == 0 && math.Signbit(fx) != math.Signbit(fy) {
+			return false
+		}
+	}
+	if c.mode&NoInf != 0 && (isInf(x) || isInf(y)) {
+		return false
+	}
+	eq, _ := numberEquals(x, y)
+	return eq
+}
+
+func (c *equalsFloat) Message(v interface{}) string {
+	return formatMsg("expected <%v> but was <%v>", c.expected, v)
+}
+
+func (c *equalsFloat) Kind() string {
+	return "EqualsFloat"
+}
+
+func (c *equalsFloat) Expected() interface{} {
+	return c.expected
+}
+
+// isFloat returns whether v is of float kind.
+func isFloat(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+// isInf returns whether v is an infinity.
+func isInf(v reflect.Value) bool {
+	return isFloat(v) && math.IsInf(v.Float(), 0)
+}
+
+// toFloat converts real number v to float64. ok is false if v is not a real number.
+func toFloat(v interface{}) (f float64, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case !isRealNumber(rv):
+		return 0, false
+	case isFloat(rv):
+		return rv.Float(), true
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	default:
+		return float64(rv.Uint()), true
+	}
+}
+
+// mustFloat is like toFloat, but panics if v is not a real number.
+func mustFloat(v interface{}) float64 {
+	f, ok := toFloat(v)
+	if !ok {
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a real number", v))
+	}
+	return f
+}
+
+type inDelta struct {
+	expected interface{}
+	delta    float64
+	relative bool // Whether delta is relative to expected.
+}
+
+// InDelta returns a cond which is true if a real number differs from the expected
+// real number by at most delta, i.e. |v - expected| <= delta.
+// NaN is not in any delta of any number.
+// InDelta panics if expected or delta is not a real number, or delta is negative.
+func InDelta(expected, delta interface{}) cond.Cond {
+	d := mustFloat(delta)
+	mustFloat(expected)
+	if !(d >= 0) {
+		panic(fmt.Sprintf("invalid delta <%v>", delta))
+	}
+	return cond.New(&inDelta{expected: expected, delta: d})
+}
+
+// InEpsilon returns a cond which is true if the relative error of a real number to
+// the expected real number is at most eps, i.e. |v - expected| / |expected| <= eps.
+// If expected is 0, only 0 meets the cond.
+// InEpsilon panics if expected or eps is not a real number, or eps is negative.
+func InEpsilon(expected, eps interface{}) cond.Cond {
+	e := mustFloat(eps)
+	mustFloat(expected)
+	if !(e >= 0) {
+		panic(fmt.Sprintf("invalid epsilon <%v>", eps))
+	}
+	return cond.New(&inDelta{expected: expected, delta: e, relative: true})
+}
+
+// diff returns the absolute or relative difference between v and c.expected.
+// ok is false if v is not a real number.
+func (c *inDelta) diff(v interface{}) (d float64, ok bool) {
+	f, ok := toFloat(v)
+	if !ok {
+		return 0, false
+	}
+	e, _ := toFloat(c.expected)
+	d = math.Abs(f - e)
+	if c.relative && d != 0 {
+		d /= math.Abs(e)
+	}
+	return d, true
+}
+
+func (c *inDelta) Test(v interface{}) bool {
+	d, ok := c.diff(v)
+	return ok && d <= c.delta
+}
+
+func (c *inDelta) Message(v interface{}) string {
+	d, ok := c.diff(v)
+	if !ok {
+		return msgf("<%[1]v(%[1]T)> is not a real number", v)
+	}
+	if c.relative {
+		return msgf("expected <%v> within relative error <%v> but was <%v> (relative error %v)",
+			formatValue(c.expected), c.delta, formatValue(v), d)
+	}
+	return msgf("expected <%v> ± <%v> but was <%v> (differs by %v)",
+		formatValue(c.expected), c.delta, formatValue(v), d)
+}
+
+func (c *inDelta) Kind() string {
+	if c.relative {
+		return "InEpsilon"
+	}
+	return "InDelta"
+}
+
+func (c *inDelta) Expected() interface{} {
+	return c.expected
+}
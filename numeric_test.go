@@ -0,0 +1,187 @@
+package asserting_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+type ID int32
+
+func TestNumeric(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(time.Second, Equals(Numeric(1000000000)))
+	t.Assert(ID(7), Equals(Numeric(uint8(7))))
+	t.Assert(Numeric(7.0), Equals(ID(7)))
+	t.Assert(Numeric(complex(7, 0)), Equals(Numeric(uint64(7))))
+	t.Assert(float32(1.5), Equals(Numeric(1.5)))
+	t.Assert(uint64(1<<63), Equals(Numeric(float64(1<<63))))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(ID(-7), Equals(Numeric(uint64(7))))
+	t.Assert(int64(1<<62+1), Equals(Numeric(float64(1<<62))))
+	t.Assert("7", Equals(Numeric(7)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "expected <7> but was <-7>" ||
+		mock.ErrorMessages[2][0] != `expected <7> but was <"7">` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(func() { Numeric("7") }, Panics("<7(string)> is not a number"))
+	if len(mock.ErrorMessages) != 3 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestEqualsLoosely(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(int64(5), EqualsLoosely(5))
+	t.Assert(float32(5), EqualsLoosely(uint8(5)))
+	t.Assert("5", EqualsLoosely("5"))
+	t.Assert((*int)(nil), EqualsLoosely(nil))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(int64(5), EqualsLoosely(6))
+	t.Assert("5", EqualsLoosely(5))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected <6> but was <5>" ||
+		mock.ErrorMessages[1][0] != `expected <5> but was <"5">` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestUntypedBoundaries(t1 *testing.T) {
+	var cases = []struct {
+		v        interface{}
+		expected interface{}
+		eq       bool
+	}{
+		{int64(math.MinInt64), UntypedInt(math.MinInt64), true},
+		{int64(math.MaxInt64), UntypedInt(math.MaxInt64), true},
+		{uint64(math.MaxInt64), UntypedInt(math.MaxInt64), true},
+		{uint64(math.MaxUint64), UntypedInt(-1), false},
+		{uint8(255), UntypedInt(-1), false},
+		{uintptr(10), UntypedInt(10), true},
+		{float64(math.MinInt64), UntypedInt(math.MinInt64), true},
+		{float64(1 << 62), UntypedInt(1<<62 + 1), false},
+		{float64(math.MaxInt64), UntypedInt(math.MaxInt64), false}, // float64(MaxInt64) is 1<<63.
+		{float32(1 << 24), UntypedInt(1<<24 + 1), false},
+		{1.5, UntypedInt(1), false},
+		{math.Inf(1), UntypedInt(math.MaxInt64), false},
+		{math.NaN(), UntypedInt(0), false},
+		{complex(1, 0), UntypedInt(1), false},
+		{uint64(math.MaxUint64), UntypedUint(math.MaxUint64), true},
+		{int64(-1), UntypedUint(math.MaxUint64), false},
+		{int8(-1), UntypedUint(255), false},
+		{int64(math.MaxInt64), UntypedUint(math.MaxInt64), true},
+		{int64(math.MaxInt64), UntypedUint(1 << 63), false},
+		{float64(1 << 63), UntypedUint(1 << 63), true},
+		{float64(math.MaxUint64), UntypedUint(math.MaxUint64), false}, // float64(MaxUint64) is 1<<64.
+		{float64(1 << 62), UntypedUint(1<<62 + 1), false},
+		{-0.0, UntypedUint(0), true},
+		{math.Inf(1), UntypedUint(math.MaxUint64), false},
+		{complex(1, 0), UntypedUint(1), false},
+	}
+
+	for i, c := range cases {
+		mock := &MockTB{TB: t1}
+		t := NewTB(mock)
+		if c.eq {
+			t.Assert(c.v, Equals(c.expected))
+			t.Assert(c.expected, Equals(c.v))
+		} else {
+			t.Assert(c.v, NotEquals(c.expected))
+			t.Assert(c.expected, NotEquals(c.v))
+		}
+		if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+			t1.Fatal(i, mock.ErrorMessages)
+		}
+	}
+}
+
+func TestEqualsFloat(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	negZero := math.Copysign(0, -1)
+	t.Assert(negZero, EqualsFloat(0.0, 0))
+	t.Assert(math.Inf(1), EqualsFloat(math.Inf(1), 0))
+	t.Assert(float32(2), EqualsFloat(2, SignedZero|NoInf))
+	t.Assert(math.NaN(), EqualsFloat(math.NaN(), NaNEqual))
+	t.Assert(float32(math.NaN()), EqualsFloat(math.NaN(), NaNEqual))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(math.NaN(), EqualsFloat(math.NaN(), 0))
+	t.Assert(negZero, EqualsFloat(0.0, SignedZero))
+	t.Assert(math.Inf(1), EqualsFloat(math.Inf(1), NoInf))
+	t.Assert("1", EqualsFloat(1, 0))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "expected <NaN(float64)> but was <NaN(float64)>" ||
+		mock.ErrorMessages[1][0] != "expected <0> but was <-0>" ||
+		mock.ErrorMessages[2][0] != "expected <+Inf(float64)> but was <+Inf(float64)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(func() { EqualsFloat(complex(1, 0), 0) }, Panics("<(1+0i)(complex128)> is not a real number"))
+	if len(mock.ErrorMessages) != 4 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestInDelta(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(0.1+0.2, InDelta(0.3, 1e-9))
+	t.Assert(float32(1.5), InDelta(1, 0.5))
+	t.Assert(uint8(9), InDelta(10, 1))
+	t.Assert(102, InEpsilon(100, 0.02))
+	t.Assert(-0.0, InEpsilon(0, 0))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(1.5, InDelta(1, 0.25))
+	t.Assert(103, InEpsilon(100, 0.02))
+	t.Assert(math.NaN(), InDelta(1, 1))
+	t.Assert(1e-300, InEpsilon(0, 0.1))
+	t.Assert("1", InDelta(1, 1))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != "expected <1> ± <0.25> but was <1.5> (differs by 0.5)" ||
+		mock.ErrorMessages[1][0] != "expected <100> within relative error <0.02> but was <103> (relative error 0.03)" ||
+		mock.ErrorMessages[2][0] != "expected <1> ± <1> but was <NaN> (differs by NaN)" ||
+		mock.ErrorMessages[3][0] != "expected <0> within relative error <0.1> but was <1e-300> (relative error +Inf)" ||
+		mock.ErrorMessages[4][0] != "<1(string)> is not a real number" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(func() { InDelta(1, -1) }, Panics("invalid delta <-1>"))
+	t.Assert(func() { InEpsilon("1", 1) }, Panics("<1(string)> is not a real number"))
+	if len(mock.ErrorMessages) != 5 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
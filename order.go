@@ -0,0 +1,172 @@
+package asserting
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/mkch/asserting/cond"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// compare compares x and y, which are both real numbers, strings or time.Time values.
+// Numbers of different types are compared by their mathematical values.
+// The result is -1 if x < y, 0 if x == y and +1 if x > y.
+// ok is false if x and y are not comparable, or either of them is NaN.
+func compare(x, y reflect.Value) (result int, ok bool) {
+	if !x.IsValid() || !y.IsValid() {
+		return 0, false
+	}
+	if isRealNumber(x) && isRealNumber(y) {
+		bx, okx := bigFloat(x)
+		by, oky := bigFloat(y)
+		if !okx || !oky {
+			return 0, false
+		}
+		return bx.Cmp(by), true
+	}
+	if x.Kind() == reflect.String && y.Kind() == reflect.String {
+		sx, sy := x.String(), y.String()
+		switch {
+		case sx < sy:
+			return -1, true
+		case sx > sy:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+	if x.Type() == timeType && y.Type() == timeType && x.CanInterface() && y.CanInterface() {
+		tx, ty := x.Interface().(time.Time), y.Interface().(time.Time)
+		switch {
+		case tx.Before(ty):
+			return -1, true
+		case tx.After(ty):
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// bigFloat converts real number v to a big.Float exactly.
+// ok is false if v is NaN.
+func bigFloat(v reflect.Value) (f *big.Float, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(v.Uint()), true
+	default:
+		if math.IsNaN(v.Float()) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(v.Float()), true
+	}
+}
+
+type ordered struct {
+	bound  interface{}
+	rel    string         // The relation to bound, e.g. "less than".
+	accept func(int) bool // Whether a comparison result meets the cond.
+	kind   string
+}
+
+// LessThan returns a cond which is true if the tested value is less than bound.
+// Both values must be real numbers, strings or time.Time values. Numbers of different
+// types, e.g. int and float64, are compared by their mathematical values, so
+// LessThan(100*time.Millisecond) works for time.Duration values.
+func LessThan(bound interface{}) cond.Cond {
+	return cond.New(&ordered{bound: bound, rel: "less than", accept: func(r int) bool { return r < 0 }, kind: "LessThan"})
+}
+
+// GreaterThan returns a cond which is true if the tested value is greater than bound.
+// See LessThan for the values which can be compared.
+func GreaterThan(bound interface{}) cond.Cond {
+	return cond.New(&ordered{bound: bound, rel: "greater than", accept: func(r int) bool { return r > 0 }, kind: "GreaterThan"})
+}
+
+// AtLeast returns a cond which is true if the tested value is greater than or equal
+// to bound. See LessThan for the values which can be compared.
+func AtLeast(bound interface{}) cond.Cond {
+	return cond.New(&ordered{bound: bound, rel: "at least", accept: func(r int) bool { return r >= 0 }, kind: "AtLeast"})
+}
+
+// AtMost returns a cond which is true if the tested value is less than or equal
+// to bound. See LessThan for the values which can be compared.
+func AtMost(bound interface{}) cond.Cond {
+	return cond.New(&ordered{bound: bound, rel: "at most", accept: func(r int) bool { return r <= 0 }, kind: "AtMost"})
+}
+
+func (c *ordered) Test(v interface{}) bool {
+	r, ok := compare(reflect.ValueOf(v), reflect.ValueOf(c.bound))
+	return ok && c.accept(r)
+}
+
+func (c *ordered) Message(v interface{}) string {
+	if _, ok := compare(reflect.ValueOf(v), reflect.ValueOf(c.bound)); !ok {
+		return msgf("<%[1]v(%[1]T)> is not comparable with <%[2]v(%[2]T)>", v, c.bound)
+	}
+	return msgf("<%v> is not %v <%v>", formatValue(v), c.rel, formatValue(c.bound))
+}
+
+func (c *ordered) Kind() string {
+	return c.kind
+}
+
+func (c *ordered) Expected() interface{} {
+	return c.bound
+}
+
+type inRange struct {
+	min, max  interface{}
+	exclusive bool // Whether min and max are excluded.
+}
+
+// InRange returns a cond which is true if the tested value is in the closed range
+// [min, max]. See LessThan for the values which can be compared.
+func InRange(min, max interface{}) cond.Cond {
+	return cond.New(&inRange{min: min, max: max})
+}
+
+// InRangeExclusive returns a cond which is like InRange, except that the range is
+// open, i.e. (min, max), which excludes min and max.
+func InRangeExclusive(min, max interface{}) cond.Cond {
+	return cond.New(&inRange{min: min, max: max, exclusive: true})
+}
+
+func (c *inRange) Test(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	lo, ok1 := compare(rv, reflect.ValueOf(c.min))
+	hi, ok2 := compare(rv, reflect.ValueOf(c.max))
+	if !ok1 || !ok2 {
+		return false
+	}
+	if c.exclusive {
+		return lo > 0 && hi < 0
+	}
+	return lo >= 0 && hi <= 0
+}
+
+func (c *inRange) Message(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	for _, bound := range []interface{}{c.min, c.max} {
+		if _, ok := compare(rv, reflect.ValueOf(bound)); !ok {
+			return msgf("<%[1]v(%[1]T)> is not comparable with <%[2]v(%[2]T)>", v, bound)
+		}
+	}
+	if c.exclusive {
+		return msgf("<%v> is not in range (%v, %v)", formatValue(v), formatValue(c.min), formatValue(c.max))
+	}
+	return msgf("<%v> is not in range [%v, %v]", formatValue(v), formatValue(c.min), formatValue(c.max))
+}
+
+func (c *inRange) Kind() string {
+	if c.exclusive {
+		return "InRangeExclusive"
+	}
+	return "InRange"
+}
@@ -0,0 +1,95 @@
+package asserting_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestLessThan(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(1, LessThan(2))
+	t.Assert(int8(-1), LessThan(uint64(0)))
+	t.Assert(1.5, LessThan(2))
+	t.Assert("a", LessThan("b"))
+	t.Assert(time.Millisecond, LessThan(time.Second))
+	t.Assert(time.Unix(1, 0), LessThan(time.Unix(2, 0)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(2, LessThan(2))
+	t.Assert("b", LessThan("a"))
+	t.Assert("1", LessThan(2))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "<2> is not less than <2>" ||
+		mock.ErrorMessages[1][0] != `<"b"> is not less than <"a">` ||
+		mock.ErrorMessages[2][0] != "<1(string)> is not comparable with <2(int)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestOrdered(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(50*time.Millisecond, LessThan(100*time.Millisecond))
+	t.Assert(2, GreaterThan(1.5))
+	t.Assert(uint8(2), AtLeast(2))
+	t.Assert("a", AtMost("a"))
+	t.That(5).IsGreaterThan(1).IsAtLeast(5).IsAtMost(5.0)
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(2, GreaterThan(2))
+	t.Assert(1, AtLeast(1.5))
+	t.Assert(time.Unix(2, 0).UTC(), AtMost(time.Unix(1, 0).UTC()))
+	t.Assert(math.NaN(), AtMost(1))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "<2> is not greater than <2>" ||
+		mock.ErrorMessages[1][0] != "<1> is not at least <1.5>" ||
+		mock.ErrorMessages[2][0] != "<1970-01-01 00:00:02 +0000 UTC> is not at most <1970-01-01 00:00:01 +0000 UTC>" ||
+		mock.ErrorMessages[3][0] != "<NaN(float64)> is not comparable with <1(int)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestInRange(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(1, InRange(1, 2))
+	t.Assert(int64(2), InRange(uint8(1), 2.0))
+	t.Assert(1.5, InRangeExclusive(1, 2))
+	t.Assert(time.Unix(5, 0), InRange(time.Unix(1, 0), time.Unix(9, 0)))
+	t.Assert(time.Second, InRangeExclusive(0, time.Minute))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(3, InRange(1, 2))
+	t.Assert(1, InRangeExclusive(1, 2))
+	t.Assert("a", InRange(1, 2))
+	t.Assert(1, InRange(0, "2"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "<3> is not in range [1, 2]" ||
+		mock.ErrorMessages[1][0] != "<1> is not in range (1, 2)" ||
+		mock.ErrorMessages[2][0] != "<a(string)> is not comparable with <1(int)>" ||
+		mock.ErrorMessages[3][0] != "<1(int)> is not comparable with <2(string)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,129 @@
+package asserting
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// recoverPanic calls f and returns the value it panics with, if any.
+func recoverPanic(f func()) (got interface{}, panicked bool) {
+	defer func() {
+		if panicked {
+			got = recover()
+		}
+	}()
+	panicked = true
+	f()
+	panicked = false
+	return
+}
+
+// panicMessage returns the string form of panic value v: the error message of an error,
+// including a runtime.Error, or what fmt.Sprint returns otherwise.
+func panicMessage(v interface{}) string {
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprint(v)
+}
+
+type panicsWithError struct {
+	target   error
+	got      interface{} // The actual recovered value.
+	panicked bool
+}
+
+// PanicsWithError returns a cond which is true if the tested function panics with an
+// error which matches target, as errors.Is reports.
+// The cond is false if the tested value is not of type func().
+func PanicsWithError(target error) cond.Cond {
+	return cond.New(&panicsWithError{target: target})
+}
+
+func (c *panicsWithError) Test(v interface{}) bool {
+	f, ok := v.(func())
+	if !ok {
+		return false
+	}
+	c.got, c.panicked = recoverPanic(f)
+	err, ok := c.got.(error)
+	return c.panicked && ok && errors.Is(err, c.target)
+}
+
+func (c *panicsWithError) Message(v interface{}) string {
+	if _, ok := v.(func()); !ok {
+		return notFuncMsg(v)
+	}
+	if !c.panicked {
+		return msgf("expected to panic with error <%v> but didn't panic", c.target)
+	}
+	if _, ok := c.got.(error); !ok {
+		return msgf("panic <%[1]v(%[1]T)> is not an error", c.got)
+	}
+	return msgf("panic error <%v> does not match <%v>", c.got, c.target)
+}
+
+func (c *panicsWithError) Kind() string {
+	return "PanicsWithError"
+}
+
+func (c *panicsWithError) Expected() interface{} {
+	return c.target
+}
+
+type panicMessageMatches struct {
+	re       *regexp.Regexp
+	got      interface{} // The actual recovered value.
+	panicked bool
+}
+
+// PanicMessageMatches returns a cond which is true if the tested function panics, and
+// the string form of the panic value contains a match of pattern, e.g.
+// `index out of range` or `assignment to entry in nil map` for runtime errors. The
+// string form is the error message if the value is an error, or what fmt.Sprint returns.
+// See MatchesRegexp for pattern, and when PanicMessageMatches panics. The cond is false if the tested value is not of type func().
+func PanicMessageMatches(pattern interface{}) cond.Cond {
+	return cond.New(&panicMessageMatches{re: compileRegexp(pattern)})
+}
+
+func (c *panicMessageMatches) Test(v interface{}) bool {
+	f, ok := v.(func())
+	if !ok {
+		return false
+	}
+	c.got, c.panicked = recoverPanic(f)
+	return c.panicked && c.re.MatchString(panicMessage(c.got))
+}
+
+func (c *panicMessageMatches) Message(v interface{}) string {
+	if _, ok := v.(func()); !ok {
+		return notFuncMsg(v)
+	}
+	if !c.panicked {
+		return msgf("expected to panic with message matching pattern `%v` but didn't panic", c.re)
+	}
+	return msgf("panic message <%v> does not match pattern `%v`", panicMessage(c.got), c.re)
+}
+
+func (c *panicMessageMatches) Kind() string {
+	return "PanicMessageMatches"
+}
+
+func (c *panicMessageMatches) Expected() interface{} {
+	return c.re
+}
+
+// AssertPanicsWithError calls t.Assert(f, PanicsWithError(target)).
+func (t TB) AssertPanicsWithError(f func(), target error) {
+	t.Helper()
+	t.Assert(f, PanicsWithError(target))
+}
+
+// AssertPanicMessageMatches calls t.Assert(f, PanicMessageMatches(pattern)).
+func (t TB) AssertPanicMessageMatches(f func(), pattern interface{}) {
+	t.Helper()
+	t.Assert(f, PanicMessageMatches(pattern))
+}
@@ -0,0 +1,63 @@
+package asserting_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestAssertPanicsWithError(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertPanicsWithError(func() { panic(io.EOF) }, io.EOF)
+	t.AssertPanicsWithError(func() { panic(fmt.Errorf("read: %w", io.EOF)) }, io.EOF)
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertPanicsWithError(func() { panic(errors.New("other")) }, io.EOF)
+	t.AssertPanicsWithError(func() { panic("EOF") }, io.EOF)
+	t.AssertPanicsWithError(func() {}, io.EOF)
+	t.Assert(1, PanicsWithError(io.EOF))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "panic error <other> does not match <EOF>" ||
+		mock.ErrorMessages[1][0] != "panic <EOF(string)> is not an error" ||
+		mock.ErrorMessages[2][0] != "expected to panic with error <EOF> but didn't panic" ||
+		mock.ErrorMessages[3][0] != "<1(int)> is not a func()" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestAssertPanicMessageMatches(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	var m map[string]int
+	var s []int
+	i := 1
+	t.AssertPanicMessageMatches(func() { m["a"] = 1 }, `assignment to entry in nil map`)
+	t.AssertPanicMessageMatches(func() { _ = s[i] }, `index out of range \[1\]`)
+	t.AssertPanicMessageMatches(func() { panic(fmt.Sprintf("bad %v", i)) }, `^bad 1$`)
+	t.AssertPanicMessageMatches(func() { panic(nil) }, `nil`)
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertPanicMessageMatches(func() { panic(42) }, `^4$`)
+	t.AssertPanicMessageMatches(func() {}, `x`)
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "panic message <42> does not match pattern `^4$`" ||
+		mock.ErrorMessages[1][0] != "expected to panic with message matching pattern `x` but didn't panic" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,82 @@
+package asserting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// Pipeline is a sequence of extractors created by Pipe.
+type Pipeline []func(v interface{}) interface{}
+
+// Pipe returns a Pipeline which extracts a value from the tested value by applying
+// extractors in order, each to the result of the previous one. Use Pipeline.Then to
+// terminate the pipeline with a condition. For example:
+//
+//	t.Assert(resp, Pipe(body, items, first, name).Then(Equals("X")))
+func Pipe(extractors ...func(v interface{}) interface{}) Pipeline {
+	return Pipeline(extractors)
+}
+
+type pipe struct {
+	extractors Pipeline
+	c          cond.Cond
+}
+
+// Then returns a cond which is true if the value extracted by p meets c.
+// If the cond is false, the failure message reports the result of every step,
+// or the step which panicked.
+func (p Pipeline) Then(c cond.Cond) cond.Cond {
+	return cond.New(&pipe{extractors: p, c: c})
+}
+
+func (c *pipe) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *pipe) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *pipe) Check(v interface{}) string {
+	steps, v, ok := c.extract(v)
+	if !ok {
+		return strings.Join(steps, "\n")
+	}
+	if ok, msg := cond.Check(c.c, v); !ok {
+		return strings.Join(append(steps, msg), "\n")
+	}
+	return ""
+}
+
+func (c *pipe) Kind() string {
+	return "Pipe"
+}
+
+// extract applies the extractors to v, and returns the description of
+// each step and the final value. ok is false if any extractor panicked.
+func (c *pipe) extract(v interface{}) (steps []string, result interface{}, ok bool) {
+	for i, f := range c.extractors {
+		name := fmt.Sprintf("step %v", i+1)
+		if fn := funcName(f); fn != "" {
+			name += " (" + fn + ")"
+		}
+		if v, ok = extractStep(f, v); !ok {
+			return append(steps, fmt.Sprintf("%v panicked: %v", name, v)), nil, false
+		}
+		steps = append(steps, fmt.Sprintf("%v: <%v>", name, formatValue(v)))
+	}
+	return steps, v, true
+}
+
+// extractStep returns f(v). If f panics, returns the recovered value and false.
+func extractStep(f func(v interface{}) interface{}, v interface{}) (result interface{}, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, ok = r, false
+		}
+	}()
+	return f(v), true
+}
@@ -0,0 +1,45 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func items(v interface{}) interface{} {
+	return v.(map[string]interface{})["items"]
+}
+
+func first(v interface{}) interface{} {
+	return v.([]interface{})[0]
+}
+
+func TestPipe(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	resp := map[string]interface{}{
+		"items": []interface{}{map[string]interface{}{"name": "X"}},
+	}
+	name := func(v interface{}) interface{} { return v.(map[string]interface{})["name"] }
+	t.Assert(resp, Pipe(items, first, name).Then(Equals("X")))
+	t.Assert(1, Pipe().Then(Equals(1)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(resp, Pipe(items, first, name).Then(Equals("Y")))
+	t.Assert(map[string]interface{}{"items": []interface{}{}}, Pipe(items, first, name).Then(Equals("X")))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != `step 1 (items): <[map[name:X]]>
+step 2 (first): <map[name:X]>
+step 3: <"X">
+expected <"Y"> but was <"X">` ||
+		mock.ErrorMessages[1][0] != `step 1 (items): <[]>
+step 2 (first) panicked: runtime error: index out of range [0] with length 0` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
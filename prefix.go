@@ -0,0 +1,44 @@
+package asserting
+
+import (
+	"fmt"
+	"testing"
+)
+
+// prefixTB is a testing.TB prefixing failure messages.
+type prefixTB struct {
+	testing.TB
+	prefix string
+}
+
+func (p prefixTB) unwrap() testing.TB {
+	return p.TB
+}
+
+func (p prefixTB) Error(args ...interface{}) {
+	p.TB.Helper()
+	p.TB.Error(p.prefix + fmt.Sprint(args...))
+}
+
+func (p prefixTB) Errorf(format string, args ...interface{}) {
+	p.TB.Helper()
+	p.TB.Error(p.prefix + fmt.Sprintf(format, args...))
+}
+
+func (p prefixTB) Fatal(args ...interface{}) {
+	p.TB.Helper()
+	p.TB.Fatal(p.prefix + fmt.Sprint(args...))
+}
+
+func (p prefixTB) Fatalf(format string, args ...interface{}) {
+	p.TB.Helper()
+	p.TB.Fatal(p.prefix + fmt.Sprintf(format, args...))
+}
+
+// WithContext returns a TB which reports failures as t does, except that failure
+// messages are prefixed with context, e.g. "while decoding header: ", so that failures
+// in helpers identify the phase of the test they came from. Contexts nest, e.g.
+// t.WithContext("a").WithContext("b") prefixes messages with "a: b: ".
+func (t TB) WithContext(context string) TB {
+	return TB{prefixTB{TB: t.TB, prefix: context + ": "}}
+}
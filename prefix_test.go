@@ -0,0 +1,32 @@
+package asserting_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestWithContext(t1 *testing.T) {
+	mock := mocktb.New(t1)
+	t := NewTB(mock)
+
+	header := t.WithContext("while decoding header")
+	header.AssertEqual(1, 1)
+	if mock.Failed() {
+		t1.Fatal(mock.Errors())
+	}
+
+	header.AssertEqual(1, 2)
+	header.WithContext("field id").Errorf("%v", "bad")
+	t.AssertEqual(3, 4)
+	header.Assert(5, Equals(6).SetFatal())
+	if !reflect.DeepEqual(mock.Errors(), []string{
+		"while decoding header: expected <2> but was <1>",
+		"while decoding header: field id: bad",
+		"expected <4> but was <3>",
+	}) || !reflect.DeepEqual(mock.Fatals(), []string{"while decoding header: expected <6> but was <5>"}) {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+}
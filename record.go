@@ -0,0 +1,112 @@
+package asserting
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// pkgPrefix is the prefix of the names of the functions in this package.
+var pkgPrefix = func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(NewTB).Pointer()).Name()
+	return name[:strings.LastIndex(name, ".")+1]
+}()
+
+// caller returns the location of the first caller outside this package.
+func caller() (file string, line int) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) {
+			return frame.File, frame.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
+
+// FailureRecord is the structured record of a failed assertion,
+// written by SetFailureLog in JSON.
+type FailureRecord struct {
+	// Test is the name of the test, see testing.TB.Name.
+	Test string `json:"test"`
+	// Name is the name of the assertion, see TB.AssertNamed.
+	Name string `json:"name,omitempty"`
+	// Kind is the kind of the failed cond, see cond.Kind.
+	Kind string `json:"kind"`
+	// Expected is the formatted value expected by the cond, if any.
+	Expected *string `json:"expected,omitempty"`
+	// Actual is the formatted tested value.
+	Actual string `json:"actual"`
+	// Message is the failure message of the cond, see cond.Message.
+	Message string `json:"message"`
+	// Fatal is whether the failure is reported with Fatal.
+	Fatal bool `json:"fatal"`
+	// File and Line are the location of the assertion, which is the first
+	// caller outside this package.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+var failureLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetFailureLog sets the writer to which every assertion failure is also written,
+// as a FailureRecord encoded in JSON, one per line, so that CI tooling can aggregate
+// failures. It returns the previous writer. A nil w disables the log, which is the
+// default. Writes are serialized.
+func SetFailureLog(w io.Writer) io.Writer {
+	failureLog.mu.Lock()
+	defer failureLog.mu.Unlock()
+	prev := failureLog.w
+	failureLog.w = w
+	return prev
+}
+
+// recordAssertion records an assertion named name of test, in which v is tested with c,
+// to the failure log, the TAP and the JUnit report, if set. msg is the failure message
+// if the assertion fails, and fatal is whether the failure is reported with Fatal.
+func recordAssertion(test, name string, c cond.Cond, v interface{}, msg string, passed, fatal bool) {
+	if !passed {
+		logFailure(test, name, c, v, msg, fatal)
+	}
+	if t := tap.Load(); t != nil {
+		t.write(test, name, c, msg, passed)
+	}
+	if r := junit.Load(); r != nil {
+		r.add(test, c, msg, passed)
+	}
+}
+
+// logFailure writes the FailureRecord of v failing c, in the assertion named name
+// of test, if the failure log is set.
+func logFailure(test, name string, c cond.Cond, v interface{}, msg string, fatal bool) {
+	failureLog.mu.Lock()
+	defer failureLog.mu.Unlock()
+	if failureLog.w == nil {
+		return
+	}
+	r := FailureRecord{
+		Test:    test,
+		Name:    name,
+		Kind:    cond.Kind(c),
+		Actual:  formatValue(v),
+		Message: msg,
+		Fatal:   fatal,
+	}
+	if expected, ok := cond.Expected(c); ok {
+		s := formatValue(expected)
+		r.Expected = &s
+	}
+	r.File, r.Line = caller()
+	json.NewEncoder(failureLog.w).Encode(&r)
+}
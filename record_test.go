@@ -0,0 +1,91 @@
+package asserting_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/cond"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestSetFailureLog(t1 *testing.T) {
+	var buf bytes.Buffer
+	prev := SetFailureLog(&buf)
+	defer SetFailureLog(prev)
+
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertEqual(1, 1)
+	if buf.Len() != 0 {
+		t1.Fatal(buf.String())
+	}
+
+	_, file, line, _ := runtime.Caller(0)
+	t.AssertEqual(1, 2)
+	t.AssertNamed("name", "a", IsNil().SetFatal())
+	if SetFailureLog(nil) != &buf {
+		t1.Fatal()
+	}
+	t.AssertEqual(3, 4)
+
+	dec := json.NewDecoder(&buf)
+	var records []FailureRecord
+	for dec.More() {
+		var r FailureRecord
+		if err := dec.Decode(&r); err != nil {
+			t1.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	expected := "2"
+	NewTB(t1).Assert(records, DeepEquals([]FailureRecord{
+		{Test: t1.Name(), Kind: "Equals", Expected: &expected, Actual: "1", Message: "expected <2> but was <1>", File: file, Line: line + 1},
+		{Test: t1.Name(), Name: "name", Kind: "IsNil", Actual: `"a"`, Message: `expected nil but was <"a"(string)>`, Fatal: true, File: file, Line: line + 2},
+	}))
+	if filepath.Base(records[0].File) != "record_test.go" {
+		t1.Fatal(records[0].File)
+	}
+}
+
+// countingCond counts the calls of Test and Message.
+type countingCond struct {
+	tests, messages int
+}
+
+func (c *countingCond) Test(v interface{}) bool {
+	c.tests++
+	return false
+}
+
+func (c *countingCond) Message(v interface{}) string {
+	c.messages++
+	return "counted"
+}
+
+func TestMessageComputedOnce(t1 *testing.T) {
+	var buf bytes.Buffer
+	prev := SetFailureLog(&buf)
+	defer SetFailureLog(prev)
+
+	counting := &countingCond{}
+	var messages []string
+	t1.Run("", func(t2 *testing.T) {
+		mock := mocktb.New(t2)
+		t := NewTB(mock)
+		t.OnFailure(func(info *FailureInfo) { messages = append(messages, info.Message) })
+		identity := func(v interface{}) interface{} { return v }
+		t.Assert(1, Pipe(identity).Then(cond.New(counting)))
+		messages = append(messages, mock.Errors()...)
+	})
+	if counting.tests != 1 || counting.messages != 1 || buf.Len() == 0 {
+		t1.Fatal(counting, buf.String())
+	}
+	if len(messages) != 2 || messages[0] != messages[1] {
+		t1.Fatal(messages)
+	}
+}
@@ -0,0 +1,99 @@
+package asserting
+
+import (
+	"container/list"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type matchesRegexp struct {
+	re *regexp.Regexp
+}
+
+// MatchesRegexp returns a cond which is true if the tested string, or []byte, contains
+// a match of pattern, which is either a string, compiled when MatchesRegexp is called,
+// or a *regexp.Regexp. Use ^ and $ to match the whole value.
+// MatchesRegexp panics if pattern is neither, or fails to compile.
+func MatchesRegexp(pattern interface{}) cond.Cond {
+	return cond.New(&matchesRegexp{re: compileRegexp(pattern)})
+}
+
+// maxRegexps is the max number of compiled string patterns cached.
+const maxRegexps = 256
+
+// regexps caches the compiled string patterns, evicting the least recently used ones
+// if there are more than maxRegexps.
+var regexps struct {
+	mu       sync.Mutex
+	patterns map[string]*list.Element // Elements of lru.
+	lru      list.List                // *regexp.Regexp, the most recently used first.
+}
+
+// compileRegexp returns pattern compiled if it is a string, or pattern itself if it is
+// a *regexp.Regexp. String patterns are cached, see maxRegexps.
+// compileRegexp panics if pattern is neither, or fails to compile.
+func compileRegexp(pattern interface{}) *regexp.Regexp {
+	switch p := pattern.(type) {
+	case string:
+		regexps.mu.Lock()
+		defer regexps.mu.Unlock()
+		if e, ok := regexps.patterns[p]; ok {
+			regexps.lru.MoveToFront(e)
+			return e.Value.(*regexp.Regexp)
+		}
+		re := regexp.MustCompile(p)
+		if regexps.patterns == nil {
+			regexps.patterns = make(map[string]*list.Element)
+		}
+		regexps.patterns[p] = regexps.lru.PushFront(re)
+		if regexps.lru.Len() > maxRegexps {
+			last := regexps.lru.Back()
+			regexps.lru.Remove(last)
+			delete(regexps.patterns, last.Value.(*regexp.Regexp).String())
+		}
+		return re
+	case *regexp.Regexp:
+		return p
+	default:
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a regexp pattern", pattern))
+	}
+}
+
+func (c *matchesRegexp) Test(v interface{}) bool {
+	s, ok := stringValue(v)
+	return ok && c.re.MatchString(s)
+}
+
+func (c *matchesRegexp) Message(v interface{}) string {
+	if _, ok := stringValue(v); !ok {
+		return msgf("<%[1]v(%[1]T)> is not a string", v)
+	}
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+	return msgf("<%v> does not match pattern `%v`", formatValue(v), c.re)
+}
+
+func (c *matchesRegexp) Kind() string {
+	return "MatchesRegexp"
+}
+
+func (c *matchesRegexp) Expected() interface{} {
+	return c.re
+}
+
+// stringValue returns the content of v if v is of string kind or []byte.
+func stringValue(v interface{}) (s string, ok bool) {
+	if b, ok := v.([]byte); ok {
+		return string(b), true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return "", false
+	}
+	return rv.String(), true
+}
@@ -0,0 +1,55 @@
+package asserting_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/cond"
+)
+
+type Path string
+
+func TestMatchesRegexp(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert("abc123", MatchesRegexp(`\d+`))
+	t.Assert([]byte("abc"), MatchesRegexp(`^abc$`))
+	t.Assert(Path("/usr/bin"), MatchesRegexp(regexp.MustCompile(`^/usr`)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert("abc", MatchesRegexp(`\d+`))
+	t.Assert([]byte("abc"), MatchesRegexp(`\d+`))
+	t.Assert(1, MatchesRegexp(`1`))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "<\"abc\"> does not match pattern `\\d+`" ||
+		mock.ErrorMessages[1][0] != "<\"abc\"> does not match pattern `\\d+`" ||
+		mock.ErrorMessages[2][0] != "<1(int)> is not a string" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(func() { MatchesRegexp(`(`) }, PanicMatches(func(interface{}) bool { return true }))
+	t.Assert(func() { MatchesRegexp(1) }, Panics("<1(int)> is not a regexp pattern"))
+	if len(mock.ErrorMessages) != 3 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	re1, _ := cond.Expected(MatchesRegexp(`^cached`))
+	re2, _ := cond.Expected(MatchesRegexp(`^cached`))
+	if re1.(*regexp.Regexp) != re2.(*regexp.Regexp) {
+		t1.Fatal("pattern compiled twice")
+	}
+	for i := 0; i < MaxRegexps; i++ {
+		MatchesRegexp(fmt.Sprintf("^evicting%v", i))
+	}
+	if re3, _ := cond.Expected(MatchesRegexp(`^cached`)); re3.(*regexp.Regexp) == re1.(*regexp.Regexp) {
+		t1.Fatal("cache not bounded")
+	}
+}
@@ -0,0 +1,83 @@
+package asserting
+
+import (
+	"sync/atomic"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// Failure describes a failed assertion.
+type Failure struct {
+	// Name is the name of the assertion, see TB.AssertNamed, or empty if none.
+	Name string
+	// Kind is the kind of the failed cond, see cond.Kind.
+	Kind string
+	// Expected is the value expected by the failed cond, if HasExpected is true.
+	Expected    interface{}
+	HasExpected bool
+	// Actual is the tested value.
+	Actual interface{}
+	// Message is the failure message of the cond, see cond.Message.
+	Message string
+}
+
+// Renderer renders the failure text reported by TB.Assert.
+type Renderer interface {
+	Render(f *Failure) string
+}
+
+// The RendererFunc type is an adapter to allow the use of ordinary functions as Renderer.
+type RendererFunc func(f *Failure) string
+
+// Render calls r(f).
+func (r RendererFunc) Render(f *Failure) string {
+	return r(f)
+}
+
+// DefaultRenderer renders the failure message of the cond as is, prefixed with the
+// name of the assertion, e.g. "status code: ", if named.
+var DefaultRenderer Renderer = RendererFunc(func(f *Failure) string {
+	if f.Name != "" {
+		return f.Name + ": " + f.Message
+	}
+	return f.Message
+})
+
+var renderer atomic.Value // Holds rendererHolder.
+
+// rendererHolder makes it possible to store values of different Renderer types in renderer.
+type rendererHolder struct {
+	Renderer
+}
+
+// SetRenderer sets the Renderer used by all TBs, and returns the previous one.
+// If r is nil, DefaultRenderer is used.
+func SetRenderer(r Renderer) Renderer {
+	if r == nil {
+		r = DefaultRenderer
+	}
+	prev := getRenderer()
+	renderer.Store(rendererHolder{r})
+	return prev
+}
+
+func getRenderer() Renderer {
+	if h, ok := renderer.Load().(rendererHolder); ok {
+		return h.Renderer
+	}
+	return DefaultRenderer
+}
+
+// render returns the failure text of v failing c with the failure message msg,
+// in the assertion named name.
+func render(name string, c cond.Cond, v interface{}, msg string) string {
+	expected, hasExpected := cond.Expected(c)
+	return getRenderer().Render(&Failure{
+		Name:        name,
+		Kind:        cond.Kind(c),
+		Expected:    expected,
+		HasExpected: hasExpected,
+		Actual:      v,
+		Message:     msg,
+	})
+}
@@ -0,0 +1,93 @@
+package asserting_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/cond"
+)
+
+type even struct{}
+
+func (even) Test(v interface{}) bool {
+	return v.(int)%2 == 0
+}
+
+func (even) Message(v interface{}) string {
+	return fmt.Sprintf("%v is odd", v)
+}
+
+func TestRenderer(t1 *testing.T) {
+	prev := SetRenderer(RendererFunc(func(f *Failure) string {
+		if f.HasExpected {
+			return fmt.Sprintf("%v|%v|%v|%v", f.Kind, f.Expected, f.Actual, f.Message)
+		}
+		return fmt.Sprintf("%v|%v|%v", f.Kind, f.Actual, f.Message)
+	}))
+	defer SetRenderer(prev)
+
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(1, Equals(2))
+	t.Assert(1, EqualsExactly(2).SetMessage("msg"))
+	t.Assert(1, cond.New(even{}))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "Equals|2|1|expected <2> but was <1>" ||
+		mock.ErrorMessages[1][0] != "EqualsExactly|2|1|msg" ||
+		mock.ErrorMessages[2][0] != "even|1|1 is odd" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	if SetRenderer(nil) == nil {
+		t1.Fatal()
+	}
+	mock.ErrorMessages = nil
+	t.Assert(1, Equals(2))
+	if len(mock.ErrorMessages) != 1 ||
+		mock.ErrorMessages[0][0] != "expected <2> but was <1>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestAssertNamed(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertNamed("status code", 200, Equals(200))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	for i, code := range []int{200, 404} {
+		t.AssertNamed(fmt.Sprintf("case %v status code", i), code, Equals(500))
+	}
+	t.AssertNamed("body", ValueError("", io.EOF), Equals(""))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "case 0 status code: expected <500> but was <200>" ||
+		mock.ErrorMessages[1][0] != "case 1 status code: expected <500> but was <404>" ||
+		mock.ErrorMessages[2][0] != "body: unexpected error <EOF>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	prev := SetRenderer(RendererFunc(func(f *Failure) string {
+		return fmt.Sprintf("%v|%v|%v", f.Name, f.Kind, f.Message)
+	}))
+	defer SetRenderer(prev)
+	mock.ErrorMessages = nil
+	t.AssertNamed("id", 1, Equals(2))
+	t.Assert(1, Equals(2))
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "id|Equals|expected <2> but was <1>" ||
+		mock.ErrorMessages[1][0] != "|Equals|expected <2> but was <1>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
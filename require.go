@@ -0,0 +1,53 @@
+package asserting
+
+import "testing"
+
+// requireTB is a testing.TB reporting errors with Fatal.
+type requireTB struct {
+	testing.TB
+}
+
+func (r requireTB) unwrap() testing.TB {
+	return r.TB
+}
+
+func (r requireTB) Error(args ...interface{}) {
+	r.TB.Helper()
+	r.TB.Fatal(args...)
+}
+
+func (r requireTB) Errorf(format string, args ...interface{}) {
+	r.TB.Helper()
+	r.TB.Fatalf(format, args...)
+}
+
+// reportsErrorsFatally returns whether t reports errors with Fatal, i.e. t is a
+// requireTB, or a wrapper passing errors to one.
+func reportsErrorsFatally(t testing.TB) bool {
+	for {
+		switch w := t.(type) {
+		case requireTB:
+			return true
+		case TB:
+			t = w.TB
+		case wrapper:
+			t = w.unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+// NewRequireTB creates a TB whose assertions stop the test with Fatal on failure,
+// as if SetFatal were called on every cond.
+func NewRequireTB(t testing.TB) TB {
+	return TB{requireTB{t}}
+}
+
+// Require returns a TB which reports failures as t does, except that assertions stop
+// the test with Fatal on failure, as if SetFatal were called on every cond, e.g.
+//
+//	t.Require().AssertEqual(err, nil)
+func (t TB) Require() TB {
+	return NewRequireTB(t.TB)
+}
@@ -0,0 +1,49 @@
+package asserting_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestRequire(t1 *testing.T) {
+	mock := mocktb.New(t1)
+	t := NewTB(mock)
+
+	t.Require().AssertEqual(1, 1)
+	NewRequireTB(mock).Assert(1, Equals(1))
+	if mock.Failed() {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+
+	t.Require().AssertEqual(1, 2)
+	if len(mock.Errors()) != 0 || !reflect.DeepEqual(mock.Fatals(), []string{"expected <2> but was <1>"}) {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+
+	mock.Reset()
+	NewRequireTB(mock).Errorf("%v", "failed")
+	if len(mock.Errors()) != 0 || !reflect.DeepEqual(mock.Fatals(), []string{"failed"}) {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+}
+
+func TestRequireSoft(t1 *testing.T) {
+	mock := mocktb.New(t1)
+	t := NewTB(mock)
+	var fatal []bool
+	t.OnFailure(func(info *FailureInfo) { fatal = append(fatal, info.Fatal) })
+
+	t.Require().Soft(func(t TB) {
+		t.AssertEqual(1, 2)
+		t.AssertEqual(3, 4)
+	})
+	if len(mock.Errors()) != 0 || !reflect.DeepEqual(mock.Fatals(), []string{"2 failures:\n[1] expected <2> but was <1>\n[2] expected <4> but was <3>"}) {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+	if !reflect.DeepEqual(fatal, []bool{true, true}) {
+		t1.Fatal(fatal)
+	}
+}
@@ -0,0 +1,94 @@
+package asserting
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// Scrubber normalizes volatile data, e.g. timestamps, in the content compared with
+// golden files, so that nondeterministic output can still be tested against them.
+type Scrubber func(s string) string
+
+// ScrubRegexp returns a Scrubber which replaces the matches of pattern with repl, as
+// regexp.Regexp.ReplaceAllString does. See MatchesRegexp for pattern, and when
+// ScrubRegexp panics.
+func ScrubRegexp(pattern interface{}, repl string) Scrubber {
+	re := compileRegexp(pattern)
+	return func(s string) string {
+		return re.ReplaceAllString(s, repl)
+	}
+}
+
+// ScrubTimestamps returns a Scrubber which replaces RFC 3339 like timestamps, e.g.
+// "2006-01-02T15:04:05.999Z" and "2006-01-02 15:04:05", with "<TIMESTAMP>".
+func ScrubTimestamps() Scrubber {
+	return ScrubRegexp(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`, "<TIMESTAMP>")
+}
+
+// ScrubUUIDs returns a Scrubber which replaces UUIDs, in their canonical textual
+// form, with "<UUID>".
+func ScrubUUIDs() Scrubber {
+	return ScrubRegexp(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`, "<UUID>")
+}
+
+// ScrubPorts returns a Scrubber which replaces the ports of loopback addresses,
+// e.g. "127.0.0.1:53412", "localhost:8080" and "[::1]:53412", with "<PORT>".
+func ScrubPorts() Scrubber {
+	return ScrubRegexp(`(127\.0\.0\.1|localhost|\[::1\]):\d+\b`, "$1:<PORT>")
+}
+
+// ScrubTempPaths returns a Scrubber which replaces paths in the directory returned by
+// os.TempDir, e.g. those created by testing.T.TempDir, with "<TEMP>". The directory
+// must be followed by a path separator, a space, a quote, or the end of the string, so
+// that e.g. "/tmpfoo" is kept.
+func ScrubTempPaths() Scrubber {
+	dir := filepath.Clean(os.TempDir())
+	return ScrubRegexp(regexp.QuoteMeta(dir)+`([/\\][^\s"'<>]*|([\s"'<>]|$))`, "<TEMP>$2")
+}
+
+// scrubbers is the Scrubbers registered with RegisterScrubber.
+var scrubbers = struct {
+	sync.Mutex
+	list []*Scrubber
+}{}
+
+// RegisterScrubber registers s to be applied by ReaderEqualsGolden to all the
+// content compared, before the Scrubbers passed to ReaderEqualsGolden. Scrubbers are
+// applied in the order registered. Calling the returned unregister removes s.
+func RegisterScrubber(s Scrubber) (unregister func()) {
+	scrubbers.Lock()
+	defer scrubbers.Unlock()
+	p := &s
+	scrubbers.list = append(scrubbers.list, p)
+	return func() {
+		scrubbers.Lock()
+		defer scrubbers.Unlock()
+		for i, s := range scrubbers.list {
+			if s == p {
+				scrubbers.list = append(scrubbers.list[:i:i], scrubbers.list[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// registeredScrubbers returns the Scrubbers registered with RegisterScrubber.
+func registeredScrubbers() []Scrubber {
+	scrubbers.Lock()
+	defer scrubbers.Unlock()
+	result := make([]Scrubber, len(scrubbers.list))
+	for i, s := range scrubbers.list {
+		result[i] = *s
+	}
+	return result
+}
+
+// scrub applies scrubbers to s in order.
+func scrub(s string, scrubbers []Scrubber) string {
+	for _, scrubber := range scrubbers {
+		s = scrubber(s)
+	}
+	return s
+}
@@ -0,0 +1,46 @@
+package asserting_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestScrubbers(t1 *testing.T) {
+	t := NewTB(t1)
+
+	t.AssertEqual(ScrubTimestamps()("at 2024-05-06T07:08:09.123456789+08:00, 2024-05-06 07:08:09Z and 2024-05-06"), "at <TIMESTAMP>, <TIMESTAMP> and 2024-05-06")
+	t.AssertEqual(ScrubUUIDs()("id=123e4567-e89b-12d3-a456-426614174000."), "id=<UUID>.")
+	t.AssertEqual(ScrubPorts()("127.0.0.1:53412 localhost:80 [::1]:1 example.com:80"), "127.0.0.1:<PORT> localhost:<PORT> [::1]:<PORT> example.com:80")
+	t.AssertEqual(ScrubTempPaths()(fmt.Sprintf("open %q: denied", filepath.Join(os.TempDir(), "Test1", "001", "a.txt"))), `open "<TEMP>": denied`)
+	tmp := filepath.Clean(os.TempDir())
+	t.AssertEqual(ScrubTempPaths()(fmt.Sprintf("%v %vfoo %q %v", tmp, tmp, tmp, filepath.Join(tmp, "a"))), `<TEMP> `+tmp+`foo "<TEMP>" <TEMP>`)
+	t.AssertEqual(ScrubRegexp(`\d+ms`, "<DURATION>")("took 12ms"), "took <DURATION>")
+}
+
+func TestReaderEqualsGoldenScrubbed(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	unregister := RegisterScrubber(ScrubUUIDs())
+	output := fmt.Sprintf("request 123e4567-e89b-12d3-a456-426614174000 at 2024-05-06T07:08:09Z from 127.0.0.1:53412, wrote %v\n",
+		filepath.Join(t1.TempDir(), "out.txt"))
+	t.Assert(strings.NewReader(output), ReaderEqualsGolden("testdata/scrubbed.golden", ScrubTimestamps(), ScrubPorts(), ScrubTempPaths()))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	unregister()
+	t.Assert(strings.NewReader(output), ReaderEqualsGolden("testdata/scrubbed.golden", ScrubTimestamps(), ScrubPorts(), ScrubTempPaths()))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 1 ||
+		mock.ErrorMessages[0][0] != "content differs from testdata/scrubbed.golden at offset 8: expected <0x3c> but was <0x31>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
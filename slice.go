@@ -0,0 +1,410 @@
+package asserting
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type sharesMemory struct {
+	other  interface{}
+	shares bool // Whether the tested slice is expected to share memory with other.
+}
+
+// SharesMemoryWith returns a cond which is true if the tested slice and slice other
+// overlap in the same backing array, counting the elements up to their capacities,
+// not only their lengths, i.e. modifications, including appends, through one slice
+// may be visible through the other. E.g. s[:1] shares memory with s[3:], because
+// appending to s[:1] overwrites s[3:].
+// The cond is false if either value is not a slice.
+func SharesMemoryWith(other interface{}) cond.Cond {
+	return cond.New(&sharesMemory{other: other, shares: true})
+}
+
+// DoesNotShareMemoryWith returns a cond which is true if the tested slice and slice other
+// don't overlap in the same backing array, counting the elements up to their
+// capacities as SharesMemoryWith does, e.g. one slice is a defensive copy of the other.
+// The cond is false if either value is not a slice.
+func DoesNotShareMemoryWith(other interface{}) cond.Cond {
+	return cond.New(&sharesMemory{other: other, shares: false})
+}
+
+func (c *sharesMemory) Test(v interface{}) bool {
+	shares, ok := sharesMemoryWith(v, c.other)
+	return ok && shares == c.shares
+}
+
+func (c *sharesMemory) Message(v interface{}) string {
+	if _, ok := sharesMemoryWith(v, c.other); !ok {
+		return msgf("<%v(%T)> and <%v(%T)> are not both slices", formatValue(v), v, formatValue(c.other), c.other)
+	}
+	if c.shares {
+		return formatMsg("<%v> does not share memory with <%v>", v, c.other)
+	}
+	return formatMsg("<%v> shares memory with <%v>", v, c.other)
+}
+
+func (c *sharesMemory) Kind() string {
+	if c.shares {
+		return "SharesMemoryWith"
+	}
+	return "DoesNotShareMemoryWith"
+}
+
+// sharesMemoryWith returns whether the capacity ranges of slice a and b overlap.
+// ok is false if either a or b is not a slice.
+func sharesMemoryWith(a, b interface{}) (shares bool, ok bool) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() != reflect.Slice || vb.Kind() != reflect.Slice {
+		return false, false
+	}
+	sizeA, sizeB := uintptr(va.Cap())*va.Type().Elem().Size(), uintptr(vb.Cap())*vb.Type().Elem().Size()
+	if sizeA == 0 || sizeB == 0 {
+		return false, true
+	}
+	startA, startB := va.Pointer(), vb.Pointer()
+	return startA < startB+sizeB && startB < startA+sizeA, true
+}
+
+type monotonic struct {
+	strict bool
+}
+
+// IsMonotonicallyIncreasing returns a cond which is true if every element of the tested
+// slice or array is greater than or equal to the previous one.
+// Elements must be real numbers, strings or time.Time values.
+// The failure message reports the first index that breaks monotonicity.
+func IsMonotonicallyIncreasing() cond.Cond {
+	return cond.New(&monotonic{})
+}
+
+// IsStrictlyIncreasing is like IsMonotonicallyIncreasing, except that every element
+// must be greater than the previous one.
+func IsStrictlyIncreasing() cond.Cond {
+	return cond.New(&monotonic{strict: true})
+}
+
+func (c *monotonic) Test(v interface{}) bool {
+	_, msg := c.check(v)
+	return msg == ""
+}
+
+func (c *monotonic) Message(v interface{}) string {
+	_, msg := c.check(v)
+	return msg
+}
+
+func (c *monotonic) Kind() string {
+	if c.strict {
+		return "IsStrictlyIncreasing"
+	}
+	return "IsMonotonicallyIncreasing"
+}
+
+// check returns the first index which breaks monotonicity and the failure message.
+// The message is empty if v is monotonic.
+func (c *monotonic) check(v interface{}) (index int, msg string) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return -1, msgf("<%[1]v(%[1]T)> is not a slice or array", v)
+	}
+	for i := 1; i < rv.Len(); i++ {
+		prev, cur := elem(rv.Index(i-1)), elem(rv.Index(i))
+		r, ok := compare(prev, cur)
+		if !ok {
+			return i, msgf("element [%v] <%v> is not comparable with element [%v] <%v>",
+				i, formatLeaf(cur), i-1, formatLeaf(prev))
+		}
+		if c.strict && r >= 0 {
+			return i, msgf("element [%v] <%v> is not greater than element [%v] <%v>",
+				i, formatLeaf(cur), i-1, formatLeaf(prev))
+		}
+		if r > 0 {
+			return i, msgf("element [%v] <%v> is less than element [%v] <%v>",
+				i, formatLeaf(cur), i-1, formatLeaf(prev))
+		}
+	}
+	return -1, ""
+}
+
+// elem returns the value contained by interface value v, or v itself if v is not an interface.
+func elem(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		return v.Elem()
+	}
+	return v
+}
+
+type elementsMatch struct {
+	expected interface{}
+}
+
+// ElementsMatch returns a cond which is true if the tested slice or array contains the
+// same elements as the expected slice or array, regardless of order, with duplicates
+// counted. Elements are compared as DeepEquals does.
+// The failure message lists the missing and the unexpected elements.
+func ElementsMatch(expected interface{}) cond.Cond {
+	return cond.New(&elementsMatch{expected: expected})
+}
+
+func (c *elementsMatch) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *elementsMatch) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *elementsMatch) Kind() string {
+	return "ElementsMatch"
+}
+
+func (c *elementsMatch) Expected() interface{} {
+	return c.expected
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *elementsMatch) Check(v interface{}) string {
+	for _, s := range []interface{}{v, c.expected} {
+		if k := reflect.ValueOf(s).Kind(); k != reflect.Slice && k != reflect.Array {
+			return msgf("<%[1]v(%[1]T)> is not a slice or array", s)
+		}
+	}
+	missing, extra := unmatched(reflect.ValueOf(c.expected), reflect.ValueOf(v))
+	if len(missing) == 0 && len(extra) == 0 {
+		return ""
+	}
+	var lines []string
+	if len(missing) > 0 {
+		lines = append(lines, msgf("missing elements: %v", formatElements(missing)))
+	}
+	if len(extra) > 0 {
+		lines = append(lines, msgf("unexpected elements: %v", formatElements(extra)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// unmatched pairs elements of slices or arrays x and y which are deeply equal, and
+// returns the elements of x and y left unpaired. No element is formatted.
+func unmatched(x, y reflect.Value) (xs, ys []interface{}) {
+	elems := make([]interface{}, y.Len())
+	for j := range elems {
+		elems[j] = y.Index(j).Interface()
+	}
+	paired := make([]bool, len(elems))
+outer:
+	for i := 0; i < x.Len(); i++ {
+		e := x.Index(i).Interface()
+		for j, elem := range elems {
+			if !paired[j] && deepEquals(e, elem) {
+				paired[j] = true
+				continue outer
+			}
+		}
+		xs = append(xs, e)
+	}
+	for j, elem := range elems {
+		if !paired[j] {
+			ys = append(ys, elem)
+		}
+	}
+	return
+}
+
+// formatElements formats elems with formatValue, truncated to the MaxElems of the
+// current Truncation.
+func formatElements(elems []interface{}) string {
+	max := getTruncation().MaxElems
+	strs := make([]string, 0, len(elems))
+	for i, e := range elems {
+		if max > 0 && i == max {
+			strs = append(strs, msgf("...(%v more)", len(elems)-i))
+			break
+		}
+		strs = append(strs, formatValue(e))
+	}
+	return "[" + strings.Join(strs, " ") + "]"
+}
+
+// collectionElements returns the elements of slice or array v, or the keys of map v.
+// ok is false if v is of other kinds.
+func collectionElements(v interface{}) (elems []interface{}, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elems = append(elems, rv.Index(i).Interface())
+		}
+	case reflect.Map:
+		for _, k := range rv.MapKeys() {
+			elems = append(elems, k.Interface())
+		}
+		sort.Slice(elems, func(i, j int) bool { return formatValue(elems[i]) < formatValue(elems[j]) })
+	default:
+		return nil, false
+	}
+	return elems, true
+}
+
+// notIn returns the elements of xs which are not deeply equal to any element of ys.
+func notIn(xs, ys []interface{}) (missing []interface{}) {
+outer:
+	for _, x := range xs {
+		for _, y := range ys {
+			if deepEquals(x, y) {
+				continue outer
+			}
+		}
+		missing = append(missing, x)
+	}
+	return
+}
+
+func notCollectionMsg(v interface{}) string {
+	return msgf("<%[1]v(%[1]T)> is not a slice, array or map", v)
+}
+
+type subsetOf struct {
+	superset interface{}
+}
+
+// SubsetOf returns a cond which is true if every element of the tested slice or array,
+// or every key of the tested map, is an element of the superset slice or array,
+// or a key of the superset map. Elements are compared as DeepEquals does, and
+// duplicates are not counted.
+// The failure message lists the elements not in superset.
+func SubsetOf(superset interface{}) cond.Cond {
+	return cond.New(&subsetOf{superset: superset})
+}
+
+func (c *subsetOf) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *subsetOf) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *subsetOf) Kind() string {
+	return "SubsetOf"
+}
+
+func (c *subsetOf) Expected() interface{} {
+	return c.superset
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *subsetOf) Check(v interface{}) string {
+	elems, ok := collectionElements(v)
+	if !ok {
+		return notCollectionMsg(v)
+	}
+	superset, ok := collectionElements(c.superset)
+	if !ok {
+		return notCollectionMsg(c.superset)
+	}
+	if extra := notIn(elems, superset); len(extra) > 0 {
+		return msgf("elements not in superset: %v", formatElements(extra))
+	}
+	return ""
+}
+
+type containsAll struct {
+	elems []interface{}
+}
+
+// ContainsAll returns a cond which is true if the tested slice or array has all the
+// elems, or the tested map has all the elems as keys. Elements are compared as
+// DeepEquals does, and duplicates are not counted.
+// The failure message lists the missing elements.
+func ContainsAll(elems ...interface{}) cond.Cond {
+	return cond.New(&containsAll{elems: elems})
+}
+
+func (c *containsAll) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *containsAll) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *containsAll) Kind() string {
+	return "ContainsAll"
+}
+
+func (c *containsAll) Expected() interface{} {
+	return c.elems
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *containsAll) Check(v interface{}) string {
+	elems, ok := collectionElements(v)
+	if !ok {
+		return notCollectionMsg(v)
+	}
+	if missing := notIn(c.elems, elems); len(missing) > 0 {
+		return msgf("missing elements: %v", formatElements(missing))
+	}
+	return ""
+}
+
+type each struct {
+	c   cond.Cond
+	msg string // The failure message of the last Test.
+}
+
+// Each returns a cond which is true if every element of the tested slice or array, or
+// every value of the tested map, meets c. The failure message lists the index or key,
+// the element and the failure message of c, of every failing element, up to 10.
+func Each(c cond.Cond) cond.Cond {
+	return cond.New(&each{c: c})
+}
+
+func (c *each) Test(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	var paths []string
+	var elems []interface{}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			paths = append(paths, fmt.Sprintf("[%v]", i))
+			elems = append(elems, rv.Index(i).Interface())
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return formatLeaf(keys[i]) < formatLeaf(keys[j]) })
+		for _, k := range keys {
+			paths = append(paths, fmt.Sprintf("[%v]", formatLeaf(k)))
+			elems = append(elems, rv.MapIndex(k).Interface())
+		}
+	default:
+		c.msg = notCollectionMsg(v)
+		return false
+	}
+	var failures []string
+	for i, e := range elems {
+		ok, msg := cond.Check(c.c, e)
+		if ok {
+			continue
+		}
+		if len(failures) == maxDiffs {
+			failures = append(failures, "...(more failures)")
+			break
+		}
+		failures = append(failures, msgf("element %v <%v>: %v", paths[i], formatValue(e), msg))
+	}
+	c.msg = strings.Join(failures, "\n")
+	return len(failures) == 0
+}
+
+func (c *each) Message(v interface{}) string {
+	return c.msg
+}
+
+func (c *each) Kind() string {
+	return "Each"
+}
@@ -0,0 +1,197 @@
+package asserting_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestSharesMemoryWith(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	a := []int{1, 2, 3, 4}
+	b := append([]int(nil), a...)
+	t.Assert(a[1:2], SharesMemoryWith(a))
+	t.Assert(a[:1], SharesMemoryWith(a[3:]))
+	t.Assert(b, DoesNotShareMemoryWith(a))
+	t.Assert(a[:0:0], DoesNotShareMemoryWith(a))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(b, SharesMemoryWith(a))
+	t.Assert(a[2:], DoesNotShareMemoryWith(a))
+	t.Assert(1, SharesMemoryWith(a))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "<[1 2 3 4]([]int)> does not share memory with <[1 2 3 4]([]int)>" ||
+		mock.ErrorMessages[1][0] != "<[3 4]> shares memory with <[1 2 3 4]>" ||
+		mock.ErrorMessages[2][0] != "<1(int)> and <[1 2 3 4]([]int)> are not both slices" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestIsMonotonicallyIncreasing(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	now := time.Now()
+	t.Assert([]int{1, 1, 2}, IsMonotonicallyIncreasing())
+	t.Assert([3]uint8{1, 2, 3}, IsStrictlyIncreasing())
+	t.Assert([]time.Time{now, now.Add(1)}, IsStrictlyIncreasing())
+	t.Assert([]interface{}{-1, uint64(1 << 63), 1e20}, IsStrictlyIncreasing())
+	t.Assert([]string(nil), IsStrictlyIncreasing())
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{1, 1, 2}, IsStrictlyIncreasing())
+	t.Assert([]float64{1, 2, 1.5}, IsMonotonicallyIncreasing())
+	t.Assert([]interface{}{1, "a"}, IsMonotonicallyIncreasing())
+	t.Assert(1, IsMonotonicallyIncreasing())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "element [1] <1> is not greater than element [0] <1>" ||
+		mock.ErrorMessages[1][0] != "element [2] <1.5> is less than element [1] <2>" ||
+		mock.ErrorMessages[2][0] != `element [1] <"a"> is not comparable with element [0] <1>` ||
+		mock.ErrorMessages[3][0] != "<1(int)> is not a slice or array" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestElementsMatch(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert([]int{3, 1, 2, 1}, ElementsMatch([]int{1, 1, 2, 3}))
+	t.Assert([2]string{"b", "a"}, ElementsMatch([]string{"a", "b"}))
+	t.Assert([][]int{{2}, {1}}, ElementsMatch([][]int{{1}, {2}}))
+	t.Assert([]int(nil), ElementsMatch([]int{}))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{3, 1, 1}, ElementsMatch([]int{1, 2, 3}))
+	t.Assert([]string{"a"}, ElementsMatch([]string{"a", "b"}))
+	t.Assert([]int{1}, ElementsMatch(1))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "missing elements: [2]\nunexpected elements: [1]" ||
+		mock.ErrorMessages[1][0] != `missing elements: ["b"]` ||
+		mock.ErrorMessages[2][0] != "<1(int)> is not a slice or array" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type formatCounted int
+
+func TestElementsMatchFormatsNothing(t1 *testing.T) {
+	var formatted int
+	RegisterFormatter(func(formatCounted) string { formatted++; return "" })
+	type item struct {
+		V formatCounted
+	}
+	var x, y []item
+	for i := 0; i < 10; i++ {
+		x = append(x, item{formatCounted(i)})
+		y = append(y, item{formatCounted(9 - i)})
+	}
+	t := NewTB(t1)
+	t.Assert(x, ElementsMatch(y))
+	t.Assert(x, SubsetOf(y))
+	t.Assert(x, ContainsAll(y[0], y[9]))
+	if formatted != 0 {
+		t1.Fatal(formatted)
+	}
+}
+
+func TestSubsetOf(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert([]int{3, 1, 1}, SubsetOf([]int{1, 2, 3}))
+	t.Assert([]int(nil), SubsetOf([]int{}))
+	t.Assert(map[string]int{"a": 1}, SubsetOf([]string{"a", "b"}))
+	t.Assert([]string{"a"}, SubsetOf(map[string]bool{"a": true}))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{4, 1, 5}, SubsetOf([]int{1, 2, 3}))
+	t.Assert(map[string]int{"c": 1, "a": 2}, SubsetOf([]string{"b"}))
+	t.Assert(1, SubsetOf([]int{1}))
+	t.Assert([]int{1}, SubsetOf("1"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "elements not in superset: [4 5]" ||
+		mock.ErrorMessages[1][0] != `elements not in superset: ["a" "c"]` ||
+		mock.ErrorMessages[2][0] != "<1(int)> is not a slice, array or map" ||
+		mock.ErrorMessages[3][0] != "<1(string)> is not a slice, array or map" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestContainsAll(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert([]int{3, 1, 2}, ContainsAll(1, 2))
+	t.Assert([]int{1}, ContainsAll())
+	t.Assert(map[string]int{"a": 1, "b": 2}, ContainsAll("a"))
+	t.Assert([][]int{{1}, {2}}, ContainsAll([]int{2}))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{3, 1, 2}, ContainsAll(1, 4, int64(2)))
+	t.Assert(map[string]int{"a": 1}, ContainsAll("b"))
+	t.Assert("abc", ContainsAll("a"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "missing elements: [4 2]" ||
+		mock.ErrorMessages[1][0] != `missing elements: ["b"]` ||
+		mock.ErrorMessages[2][0] != "<abc(string)> is not a slice, array or map" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestEach(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert([]int{2, 4}, Each(Matches(isEven)))
+	t.Assert([0]int{}, Each(Equals(1)))
+	t.Assert(map[string]int{"a": 1, "b": 2}, Each(GreaterThan(0)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([]int{2, 3, 4, 5}, Each(Matches(isEven)))
+	t.Assert(map[string]int{"b": -2, "a": 1, "c": 0}, Each(GreaterThan(0)))
+	t.Assert(make([]int, 20), Each(Equals(1)))
+	t.Assert(1, Each(Equals(1)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "element [1] <3>: unexpected <3>, expected: isEven\nelement [3] <5>: unexpected <5>, expected: isEven" ||
+		mock.ErrorMessages[1][0] != "element [\"b\"] <-2>: <-2> is not greater than <0>\nelement [\"c\"] <0>: <0> is not greater than <0>" ||
+		strings.Count(mock.ErrorMessages[2][0].(string), "\n") != 10 ||
+		!strings.HasSuffix(mock.ErrorMessages[2][0].(string), "\n...(more failures)") ||
+		mock.ErrorMessages[3][0] != "<1(int)> is not a slice, array or map" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
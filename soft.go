@@ -0,0 +1,117 @@
+package asserting
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// softTB is a testing.TB collecting failures instead of reporting them.
+type softTB struct {
+	testing.TB
+	mu       sync.Mutex
+	failures []string
+	failed   bool // Whether Fail has been called.
+	fatal    bool // Whether FailNow has been called.
+}
+
+func (s *softTB) unwrap() testing.TB {
+	return s.TB
+}
+
+// softFailNow is the panic value of softTB.FailNow, which stops the soft block.
+type softFailNow struct{}
+
+func (s *softTB) record(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, msg)
+	s.failed = true
+}
+
+func (s *softTB) Error(args ...interface{}) {
+	s.record(fmt.Sprint(args...))
+}
+
+func (s *softTB) Errorf(format string, args ...interface{}) {
+	s.record(fmt.Sprintf(format, args...))
+}
+
+func (s *softTB) Fatal(args ...interface{}) {
+	s.record(fmt.Sprint(args...))
+	s.FailNow()
+}
+
+func (s *softTB) Fatalf(format string, args ...interface{}) {
+	s.record(fmt.Sprintf(format, args...))
+	s.FailNow()
+}
+
+func (s *softTB) Fail() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = true
+}
+
+func (s *softTB) FailNow() {
+	s.mu.Lock()
+	s.failed, s.fatal = true, true
+	s.mu.Unlock()
+	panic(softFailNow{})
+}
+
+func (s *softTB) Failed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failed || s.TB.Failed()
+}
+
+// report reports the collected failures through the underlying testing.TB as one
+// failure, with Fatal if FailNow has been called, or Error otherwise.
+func (s *softTB) report() {
+	s.TB.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.failed {
+		return
+	}
+	var msg string
+	switch len(s.failures) {
+	case 0:
+		msg = "soft assertions failed"
+	case 1:
+		msg = s.failures[0]
+	default:
+		var b strings.Builder
+		fmt.Fprintf(&b, "%v failures:", len(s.failures))
+		for i, failure := range s.failures {
+			fmt.Fprintf(&b, "\n[%v] %v", i+1, failure)
+		}
+		msg = b.String()
+	}
+	if s.fatal {
+		s.TB.Fatal(msg)
+	} else {
+		s.TB.Error(msg)
+	}
+}
+
+// Soft calls f with a TB collecting the failures of assertions, instead of reporting
+// them immediately, and reports all of them together as one failure when f returns,
+// so that every mismatch, e.g. of the fields of a table, shows in one run.
+// A failure reported with Fatal in f stops f, and the collected failures are reported
+// with Fatal.
+func (t TB) Soft(f func(t TB)) {
+	t.Helper()
+	s := &softTB{TB: t.TB}
+	defer s.report()
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(softFailNow); !ok {
+				panic(r)
+			}
+		}
+	}()
+	f(TB{s})
+}
@@ -0,0 +1,40 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestSoft(t1 *testing.T) {
+	mock := mocktb.New(t1)
+	t := NewTB(mock)
+
+	t.Soft(func(t TB) {
+		t.AssertEqual(1, 1)
+	})
+	if mock.Failed() {
+		t1.Fatal(mock.Errors())
+	}
+
+	t.Soft(func(t TB) {
+		t.AssertEqual(1, 2)
+		if len(mock.Errors()) != 0 || !t.Failed() {
+			t1.Fatal("failure reported immediately")
+		}
+	})
+	t.Soft(func(t TB) {
+		t.AssertEqual(1, 2)
+		t.AssertEqual("a", "b")
+		t.Assert(3, Equals(4).SetFatal())
+		t.AssertEqual(5, 6)
+	})
+	if len(mock.Errors()) != 1 || mock.Errors()[0] != "expected <2> but was <1>" ||
+		len(mock.Fatals()) != 1 || mock.Fatals()[0] != "3 failures:\n"+
+		"[1] expected <2> but was <1>\n"+
+		`[2] expected <"b"> but was <"a">`+"\n"+
+		"[3] expected <4> but was <3>" {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+}
@@ -0,0 +1,139 @@
+package asserting
+
+import (
+	"fmt"
+	"sync"
+)
+
+// KindStats is the statistics of the assertions of a kind of cond.
+type KindStats struct {
+	// Assertions is the number of assertions made.
+	Assertions int
+	// Passes is the number of passed assertions.
+	Passes int
+	// Failures is the number of failed assertions.
+	Failures int
+}
+
+// Stats is the statistics of assertions made with TB.Assert,
+// including the methods calling it.
+type Stats struct {
+	KindStats
+	// Kinds maps cond kinds, see cond.Kind, to the statistics of assertions
+	// of that kind.
+	Kinds map[string]KindStats
+}
+
+func (s *Stats) add(kind string, failed bool) {
+	if s.Kinds == nil {
+		s.Kinds = make(map[string]KindStats)
+	}
+	k := s.Kinds[kind]
+	k.Assertions++
+	s.Assertions++
+	if failed {
+		k.Failures++
+		s.Failures++
+	} else {
+		k.Passes++
+		s.Passes++
+	}
+	s.Kinds[kind] = k
+}
+
+func (s *Stats) clone() Stats {
+	c := *s
+	c.Kinds = make(map[string]KindStats, len(s.Kinds))
+	for k, v := range s.Kinds {
+		c.Kinds[k] = v
+	}
+	return c
+}
+
+var stats struct {
+	mu     sync.Mutex
+	global Stats
+	tests  map[string]*Stats // Keyed by test names.
+}
+
+// recordStats records an assertion of kind made in the test of t.
+func recordStats(t TB, kind string, failed bool) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	stats.global.add(kind, failed)
+	t.statsLocked().add(kind, failed)
+}
+
+// statsLocked returns the statistics of the test of t, creating an empty one if none.
+// The statistics are deleted when the test finishes, after the cleanup functions
+// registered after the creation, so that they can read the statistics.
+// stats.mu must be held.
+func (t TB) statsLocked() *Stats {
+	name := t.Name()
+	if s := stats.tests[name]; s != nil {
+		return s
+	}
+	if stats.tests == nil {
+		stats.tests = make(map[string]*Stats)
+	}
+	s := &Stats{}
+	stats.tests[name] = s
+	t.Cleanup(func() {
+		stats.mu.Lock()
+		defer stats.mu.Unlock()
+		delete(stats.tests, name)
+	})
+	return s
+}
+
+// Stats returns the statistics of the assertions made so far in the test
+// of t, identified by t.Name(), excluding subtests.
+func (t TB) Stats() Stats {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if s := stats.tests[t.Name()]; s != nil {
+		return s.clone()
+	}
+	return Stats{Kinds: map[string]KindStats{}}
+}
+
+// GlobalStats returns the statistics of all the assertions made so far,
+// in all tests.
+func GlobalStats() Stats {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	return stats.global.clone()
+}
+
+// LogStatsAtEnd logs a summary of the statistics of the assertions made in the test of
+// t when the test finishes, e.g. "assertions: 10, passed: 9, failed: 1", or
+// "no assertions made", which helps to find tests silently asserting nothing.
+func (t TB) LogStatsAtEnd() {
+	t.keepStats()
+	t.Cleanup(func() {
+		if s := t.Stats(); s.Assertions == 0 {
+			t.Log("no assertions made")
+		} else {
+			t.Log(fmt.Sprintf("assertions: %v, passed: %v, failed: %v", s.Assertions, s.Passes, s.Failures))
+		}
+	})
+}
+
+// ExpectAssertions reports a failure when the test of t finishes, if no assertion has
+// been made in the test, excluding subtests.
+func (t TB) ExpectAssertions() {
+	t.keepStats()
+	t.Cleanup(func() {
+		if t.Stats().Assertions == 0 {
+			t.Error("no assertions made")
+		}
+	})
+}
+
+// keepStats makes sure the statistics of the test of t are kept until the cleanup
+// functions registered after it return.
+func (t TB) keepStats() {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	t.statsLocked()
+}
@@ -0,0 +1,96 @@
+package asserting_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestStats(t1 *testing.T) {
+	t := NewTB(mocktb.New(t1))
+	global := GlobalStats()
+
+	t.AssertEqual(1, 1)
+	t.AssertEqual(1, 2)
+	t.AssertNotEqual(1, 1)
+	t.Assert(1, Matches(func(interface{}) bool { return true }))
+
+	expected := Stats{
+		KindStats: KindStats{Assertions: 4, Passes: 2, Failures: 2},
+		Kinds: map[string]KindStats{
+			"Equals":    {Assertions: 2, Passes: 1, Failures: 1},
+			"NotEquals": {Assertions: 1, Failures: 1},
+			"Matches":   {Assertions: 1, Passes: 1},
+		},
+	}
+	if s := t.Stats(); !reflect.DeepEqual(s, expected) {
+		t1.Fatal(s)
+	}
+	if s := GlobalStats(); s.Assertions-global.Assertions != 4 || s.Failures-global.Failures != 2 ||
+		s.Kinds["NotEquals"].Failures-global.Kinds["NotEquals"].Failures != 1 {
+		t1.Fatal(s, global)
+	}
+	if s := NewTB(mocktb.New(t1)).Stats(); s.Assertions != 4 {
+		t1.Fatal(s)
+	}
+}
+
+func TestLogStatsAtEnd(t1 *testing.T) {
+	var mocks []*mocktb.TB
+	for _, n := range []int{0, 2} {
+		t1.Run("", func(t2 *testing.T) {
+			mock := mocktb.New(t2)
+			mocks = append(mocks, mock)
+			t := NewTB(mock)
+			t.LogStatsAtEnd()
+			t.ExpectAssertions()
+			for i := 0; i < n; i++ {
+				t.AssertEqual(i, 1)
+			}
+		})
+	}
+	if !reflect.DeepEqual(mocks[0].Logs(), []string{"no assertions made"}) ||
+		!reflect.DeepEqual(mocks[0].Errors(), []string{"no assertions made"}) ||
+		!reflect.DeepEqual(mocks[1].Logs(), []string{"assertions: 2, passed: 1, failed: 1"}) ||
+		!reflect.DeepEqual(mocks[1].Errors(), []string{"expected <1> but was <0>"}) {
+		t1.Fatal(mocks[0].Logs(), mocks[0].Errors(), mocks[1].Logs(), mocks[1].Errors())
+	}
+}
+
+// rerunTB is a testing.TB with a fixed name, whose cleanup functions are called
+// by finish, emulating reruns of a test, as with go test -count=2.
+type rerunTB struct {
+	*mocktb.TB
+	cleanups []func()
+}
+
+func (r *rerunTB) Name() string { return "rerun" }
+
+func (r *rerunTB) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+func (r *rerunTB) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func TestStatsRerun(t1 *testing.T) {
+	first := &rerunTB{TB: mocktb.New(t1)}
+	t := NewTB(first)
+	t.ExpectAssertions()
+	t.AssertEqual(1, 1)
+	first.finish()
+
+	second := &rerunTB{TB: mocktb.New(t1)}
+	t = NewTB(second)
+	t.ExpectAssertions()
+	if s := t.Stats(); s.Assertions != 0 {
+		t1.Fatal(s)
+	}
+	second.finish()
+	if len(first.Errors()) != 0 || !reflect.DeepEqual(second.Errors(), []string{"no assertions made"}) {
+		t1.Fatal(first.Errors(), second.Errors())
+	}
+}
@@ -0,0 +1,78 @@
+package asserting
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// summaryTB is a testing.TB recording the failures reported through it.
+type summaryTB struct {
+	testing.TB
+	mu       sync.Mutex
+	failures []string
+}
+
+func (s *summaryTB) unwrap() testing.TB {
+	return s.TB
+}
+
+func (s *summaryTB) record(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, msg)
+}
+
+func (s *summaryTB) Error(args ...interface{}) {
+	s.TB.Helper()
+	s.record(fmt.Sprint(args...))
+	s.TB.Error(args...)
+}
+
+func (s *summaryTB) Errorf(format string, args ...interface{}) {
+	s.TB.Helper()
+	s.record(fmt.Sprintf(format, args...))
+	s.TB.Errorf(format, args...)
+}
+
+func (s *summaryTB) Fatal(args ...interface{}) {
+	s.TB.Helper()
+	s.record(fmt.Sprint(args...))
+	s.TB.Fatal(args...)
+}
+
+func (s *summaryTB) Fatalf(format string, args ...interface{}) {
+	s.TB.Helper()
+	s.record(fmt.Sprintf(format, args...))
+	s.TB.Fatalf(format, args...)
+}
+
+// summary returns the summary of the recorded failures, or an empty string if none.
+func (s *summaryTB) summary() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch len(s.failures) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("1 failure: %v", s.failures[0])
+	default:
+		return fmt.Sprintf("%v failures\nfirst: %v\nlast: %v", len(s.failures),
+			s.failures[0], s.failures[len(s.failures)-1])
+	}
+}
+
+// WithFailureSummary returns a TB which reports failures as t does, and at the end of
+// the test, if any failure has been reported through it, logs a summary of the count,
+// the first and the last failure messages. The summary makes the failures of long
+// table-driven tests easier to find.
+func (t TB) WithFailureSummary() TB {
+	s := &summaryTB{TB: t.TB}
+	t.Cleanup(func() {
+		if summary := s.summary(); summary != "" {
+			s.TB.Log(strings.Replace("failure summary: "+summary, "\n", "\n\t", -1))
+		}
+	})
+	return TB{s}
+}
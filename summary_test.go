@@ -0,0 +1,31 @@
+package asserting_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestWithFailureSummary(t1 *testing.T) {
+	var mocks []*mocktb.TB
+	for _, n := range []int{0, 1, 3} {
+		t1.Run("", func(t2 *testing.T) {
+			mock := mocktb.New(t2)
+			mocks = append(mocks, mock)
+			t := NewTB(mock).WithFailureSummary()
+			for i := 0; i < n; i++ {
+				t.AssertEqual(i, -1)
+			}
+		})
+	}
+	if len(mocks[0].Logs()) != 0 ||
+		!reflect.DeepEqual(mocks[1].Logs(), []string{"failure summary: 1 failure: expected <-1> but was <0>"}) ||
+		!reflect.DeepEqual(mocks[2].Logs(), []string{"failure summary: 3 failures\n\tfirst: expected <-1> but was <0>\n\tlast: expected <-1> but was <2>"}) {
+		t1.Fatal(mocks[0].Logs(), mocks[1].Logs(), mocks[2].Logs())
+	}
+	if len(mocks[2].Errors()) != 3 {
+		t1.Fatal(mocks[2].Errors())
+	}
+}
@@ -0,0 +1,140 @@
+package asserting
+
+import (
+	"sync"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// syncMapSnapshot copies the contents of v if v is a *sync.Map.
+func syncMapSnapshot(v interface{}) (m map[interface{}]interface{}, ok bool) {
+	sm, ok := v.(*sync.Map)
+	if !ok || sm == nil {
+		return nil, false
+	}
+	m = make(map[interface{}]interface{})
+	sm.Range(func(key, value interface{}) bool {
+		m[key] = value
+		return true
+	})
+	return m, true
+}
+
+func notSyncMapMsg(v interface{}) string {
+	return msgf("<%[1]v(%[1]T)> is not a *sync.Map", v)
+}
+
+type syncMapEquals struct {
+	expected map[interface{}]interface{}
+}
+
+// SyncMapEquals returns a cond which is true if the tested *sync.Map contains exactly
+// the entries of expected. The contents of the sync.Map are snapshotted with Range,
+// and the values are compared deeply, as DeepEquals does.
+func SyncMapEquals(expected map[interface{}]interface{}) cond.Cond {
+	return cond.New(&syncMapEquals{expected: expected})
+}
+
+func (c *syncMapEquals) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *syncMapEquals) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *syncMapEquals) Kind() string {
+	return "SyncMapEquals"
+}
+
+func (c *syncMapEquals) Expected() interface{} {
+	return c.expected
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *syncMapEquals) Check(v interface{}) string {
+	m, ok := syncMapSnapshot(v)
+	if !ok {
+		return notSyncMapMsg(v)
+	}
+	expected := c.expected
+	if expected == nil {
+		expected = map[interface{}]interface{}{}
+	}
+	if d := diff(expected, m, false); d != "" {
+		return msgf("sync.Map mismatch:\n%v", d)
+	}
+	return ""
+}
+
+type syncMapHasKey struct {
+	key   interface{}
+	conds []cond.Cond
+}
+
+// SyncMapHasKey returns a cond which is true if the tested *sync.Map has the key.
+// If any cond is provided, the value of the key must also meet all of them.
+func SyncMapHasKey(key interface{}, conds ...cond.Cond) cond.Cond {
+	return cond.New(&syncMapHasKey{key: key, conds: conds})
+}
+
+func (c *syncMapHasKey) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *syncMapHasKey) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *syncMapHasKey) Kind() string {
+	return "SyncMapHasKey"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *syncMapHasKey) Check(v interface{}) string {
+	sm, ok := v.(*sync.Map)
+	if !ok || sm == nil {
+		return notSyncMapMsg(v)
+	}
+	value, ok := sm.Load(c.key)
+	if !ok {
+		return msgf("sync.Map has no key <%v>", formatValue(c.key))
+	}
+	for _, vc := range c.conds {
+		if ok, msg := cond.Check(vc, value); !ok {
+			return msgf("key <%v> of sync.Map: %v", formatValue(c.key), msg)
+		}
+	}
+	return ""
+}
+
+type syncMapNoKey struct {
+	key interface{}
+}
+
+// SyncMapHasNoKey returns a cond which is true if the tested *sync.Map doesn't have the key.
+func SyncMapHasNoKey(key interface{}) cond.Cond {
+	return cond.New(&syncMapNoKey{key: key})
+}
+
+func (c *syncMapNoKey) Test(v interface{}) bool {
+	sm, ok := v.(*sync.Map)
+	if !ok || sm == nil {
+		return false
+	}
+	_, ok = sm.Load(c.key)
+	return !ok
+}
+
+func (c *syncMapNoKey) Message(v interface{}) string {
+	sm, ok := v.(*sync.Map)
+	if !ok || sm == nil {
+		return notSyncMapMsg(v)
+	}
+	value, _ := sm.Load(c.key)
+	return msgf("unexpected key <%v> of sync.Map with value <%v>", formatValue(c.key), formatValue(value))
+}
+
+func (c *syncMapNoKey) Kind() string {
+	return "SyncMapHasNoKey"
+}
@@ -0,0 +1,45 @@
+package asserting_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestSyncMap(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	var m sync.Map
+	t.Assert(&m, SyncMapEquals(nil))
+	m.Store("a", 1)
+	m.Store(2, []int{2})
+	t.Assert(&m, SyncMapEquals(map[interface{}]interface{}{"a": 1, 2: []int{2}}))
+	t.Assert(&m, SyncMapHasKey("a"))
+	t.Assert(&m, SyncMapHasKey(2, EqualsSlice([]int{2})))
+	t.Assert(&m, SyncMapHasNoKey("b"))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(&m, SyncMapEquals(map[interface{}]interface{}{"a": 2, "b": 3}))
+	t.Assert(&m, SyncMapHasKey("b"))
+	t.Assert(&m, SyncMapHasKey("a", Equals(2)))
+	t.Assert(&m, SyncMapHasNoKey("a"))
+	t.Assert(map[string]int{}, SyncMapHasKey("a"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != `sync.Map mismatch:
+["a"]: expected <2> but was <1>
+["b"]: missing
+[2]: unexpected <[2]>` ||
+		mock.ErrorMessages[1][0] != `sync.Map has no key <"b">` ||
+		mock.ErrorMessages[2][0] != `key <"a"> of sync.Map: expected <2> but was <1>` ||
+		mock.ErrorMessages[3][0] != `unexpected key <"a"> of sync.Map with value <1>` ||
+		mock.ErrorMessages[4][0] != "<map[](map[string]int)> is not a *sync.Map" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
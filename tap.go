@@ -0,0 +1,66 @@
+package asserting
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// TAP writes every assertion made with TB.Assert, including the methods calling it,
+// as a line of the Test Anything Protocol, version 13, e.g. "ok 1 - TestAdd: Equals".
+// Failures are followed by YAML diagnostics with the message and the location.
+type TAP struct {
+	mu sync.Mutex
+	w  io.Writer
+	n  int // Number of assertions written.
+}
+
+// NewTAP creates a TAP writing to w, and writes the TAP version line.
+// Use SetTAP to start writing assertions.
+func NewTAP(w io.Writer) *TAP {
+	fmt.Fprintln(w, "TAP version 13")
+	return &TAP{w: w}
+}
+
+// Close writes the plan line, e.g. "1..42", with the number of assertions written.
+// Close does not close the underlying writer.
+func (t *TAP) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, err := fmt.Fprintf(t.w, "1..%v\n", t.n)
+	return err
+}
+
+// write writes an assertion, with the failure message msg if it fails.
+func (t *TAP) write(test, name string, c cond.Cond, msg string, passed bool) {
+	desc := test + ": " + cond.Kind(c)
+	if name != "" {
+		desc = test + ": " + name + " (" + cond.Kind(c) + ")"
+	}
+	var diag string
+	if !passed {
+		file, line := caller()
+		diag = fmt.Sprintf("  ---\n  message: %v\n  at: %v\n  ...\n",
+			strconv.Quote(msg), strconv.Quote(fmt.Sprintf("%v:%v", file, line)))
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.n++
+	status := "ok"
+	if !passed {
+		status = "not ok"
+	}
+	fmt.Fprintf(t.w, "%v %v - %v\n%v", status, t.n, desc, diag)
+}
+
+var tap atomic.Pointer[TAP]
+
+// SetTAP sets the TAP to which all assertions are written, and returns the previous
+// one. A nil t stops writing, which is the default.
+func SetTAP(t *TAP) *TAP {
+	return tap.Swap(t)
+}
@@ -0,0 +1,41 @@
+package asserting_test
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestTAP(t1 *testing.T) {
+	var buf bytes.Buffer
+	tap := NewTAP(&buf)
+	prev := SetTAP(tap)
+	defer SetTAP(prev)
+
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertEqual(1, 1)
+	_, file, line, _ := runtime.Caller(0)
+	t.AssertNamed("id", 1, Equals(2))
+	if SetTAP(nil) != tap {
+		t1.Fatal()
+	}
+	t.AssertEqual(3, 4)
+	if err := tap.Close(); err != nil {
+		t1.Fatal(err)
+	}
+
+	NewTB(t1).AssertEqual(buf.String(), fmt.Sprintf(`TAP version 13
+ok 1 - TestTAP: Equals
+not ok 2 - TestTAP: id (Equals)
+  ---
+  message: "expected <2> but was <1>"
+  at: "%v:%v"
+  ...
+1..2
+`, file, line+1))
+}
@@ -0,0 +1,74 @@
+package asserting
+
+import "github.com/mkch/asserting/cond"
+
+// Subject is a value to make chained assertions on, see TB.That.
+type Subject struct {
+	t TB
+	v interface{}
+}
+
+// That returns a Subject of v, which asserts v meets conds in a chain, e.g.
+//
+//	t.That(got).Equals(5).Is(LessThan(10))
+//
+// Every check of the chain is made, even if a previous one fails, unless the failure
+// is fatal.
+func (t TB) That(v interface{}) *Subject {
+	return &Subject{t: t, v: v}
+}
+
+// Is calls t.Assert(v, c), where v is the value of s, and returns s.
+func (s *Subject) Is(c cond.Cond) *Subject {
+	s.t.Helper()
+	s.t.Assert(s.v, c)
+	return s
+}
+
+// Equals asserts the value of s equals to expected, see Equals.
+func (s *Subject) Equals(expected interface{}) *Subject {
+	s.t.Helper()
+	return s.Is(Equals(expected))
+}
+
+// NotEquals asserts the value of s does not equal to expected, see NotEquals.
+func (s *Subject) NotEquals(expected interface{}) *Subject {
+	s.t.Helper()
+	return s.Is(NotEquals(expected))
+}
+
+// EqualsSlice asserts the value of s equals to the expected slice, see EqualsSlice.
+func (s *Subject) EqualsSlice(expected interface{}) *Subject {
+	s.t.Helper()
+	return s.Is(EqualsSlice(expected))
+}
+
+// Matches asserts f returns true for the value of s, see Matches.
+func (s *Subject) Matches(f func(v interface{}) bool) *Subject {
+	s.t.Helper()
+	return s.Is(Matches(f))
+}
+
+// IsLessThan asserts the value of s is less than bound, see LessThan.
+func (s *Subject) IsLessThan(bound interface{}) *Subject {
+	s.t.Helper()
+	return s.Is(LessThan(bound))
+}
+
+// IsGreaterThan asserts the value of s is greater than bound, see GreaterThan.
+func (s *Subject) IsGreaterThan(bound interface{}) *Subject {
+	s.t.Helper()
+	return s.Is(GreaterThan(bound))
+}
+
+// IsAtLeast asserts the value of s is greater than or equal to bound, see AtLeast.
+func (s *Subject) IsAtLeast(bound interface{}) *Subject {
+	s.t.Helper()
+	return s.Is(AtLeast(bound))
+}
+
+// IsAtMost asserts the value of s is less than or equal to bound, see AtMost.
+func (s *Subject) IsAtMost(bound interface{}) *Subject {
+	s.t.Helper()
+	return s.Is(AtMost(bound))
+}
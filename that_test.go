@@ -0,0 +1,32 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestThat(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.That(5).Equals(5).IsLessThan(10).NotEquals(4).
+		Is(Matches(isEven).SetMessage("odd")).
+		Matches(func(v interface{}) bool { return v.(int) > 0 })
+	t.That([]int{1}).EqualsSlice([]int{1})
+	if len(mock.ErrorMessages) != 1 || mock.ErrorMessages[0][0] != "odd" || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+	mock.ErrorMessages = nil
+
+	t.That(12).Equals(5).IsLessThan(10)
+	t.That(1).Is(Equals(2).SetFatal())
+	if len(mock.FatalMessages) != 1 || mock.FatalMessages[0][0] != "expected <2> but was <1>" {
+		t1.Fatal(mock.FatalMessages)
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected <5> but was <12>" ||
+		mock.ErrorMessages[1][0] != "<12> is not less than <10>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
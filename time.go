@@ -0,0 +1,60 @@
+package asserting
+
+import (
+	"time"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// timeMsg returns the failure message of comparing time.Time values.
+// Times are formatted by formatTime, along with their difference.
+func timeMsg(format string, expected, actual time.Time) string {
+	return msgf(format+" (differs by %v)", formatTime(expected), formatTime(actual), actual.Sub(expected))
+}
+
+// formatTime formats t with the formatter registered with RegisterFormatter, or in
+// RFC 3339 without the monotonic clock reading.
+func formatTime(t time.Time) string {
+	if str, ok := customFormat(t); ok {
+		return str
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+type withinDuration struct {
+	expected time.Time
+	d        time.Duration
+}
+
+// WithinDuration returns a cond which is true if a time.Time value is within d of
+// expected, before or after. Times are compared as instants, regardless of locations
+// and monotonic clock readings.
+func WithinDuration(expected time.Time, d time.Duration) cond.Cond {
+	return cond.New(&withinDuration{expected: expected, d: d})
+}
+
+func (c *withinDuration) Test(v interface{}) bool {
+	t, ok := v.(time.Time)
+	if !ok {
+		return false
+	}
+	diff := t.Sub(c.expected)
+	return diff >= -c.d && diff <= c.d
+}
+
+func (c *withinDuration) Message(v interface{}) string {
+	t, ok := v.(time.Time)
+	if !ok {
+		return msgf("<%[1]v(%[1]T)> is not a time.Time", v)
+	}
+	return msgf("expected <%v> ± %v but was <%v> (differs by %v)",
+		formatTime(c.expected), c.d, formatTime(t), t.Sub(c.expected))
+}
+
+func (c *withinDuration) Kind() string {
+	return "WithinDuration"
+}
+
+func (c *withinDuration) Expected() interface{} {
+	return c.expected
+}
@@ -0,0 +1,76 @@
+package asserting_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestEqualsTime(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	now := time.Now()
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	t.Assert(now, Equals(now.Round(0)))
+	t.Assert(now.In(loc), Equals(now))
+	t.Assert(now, NotEquals(now.Add(1)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t0 := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Assert(t0.Add(1500*time.Millisecond).In(loc), Equals(t0))
+	t.Assert(t0.In(loc), EqualsExactly(t0))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected <2020-01-02T03:04:05Z> but was <2020-01-02T11:04:06.5+08:00> (differs by 1.5s)" ||
+		mock.ErrorMessages[1][0] != "expected <2020-01-02T03:04:05Z> but was <2020-01-02T11:04:05+08:00> (differs by 0s)" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestTimeFormatter(t1 *testing.T) {
+	RegisterFormatter(func(t time.Time) string { return t.UTC().Format(time.DateTime) })
+	defer UnregisterFormatter[time.Time]()
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t0 := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Assert(t0.Add(time.Hour), Equals(t0))
+	t.Assert(t0.Add(time.Hour), WithinDuration(t0, time.Minute))
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected <2020-01-02 03:04:05> but was <2020-01-02 04:04:05> (differs by 1h0m0s)" ||
+		mock.ErrorMessages[1][0] != "expected <2020-01-02 03:04:05> ± 1m0s but was <2020-01-02 04:04:05> (differs by 1h0m0s)" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestWithinDuration(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	now := time.Now()
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	t.Assert(now.Add(time.Second), WithinDuration(now, time.Second))
+	t.Assert(now.Add(-time.Second).In(loc), WithinDuration(now, time.Second))
+	t.Assert(now.Round(0), WithinDuration(now, 0))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t0 := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Assert(t0.Add(-1500*time.Millisecond), WithinDuration(t0, time.Second))
+	t.Assert(t0.Unix(), WithinDuration(t0, time.Second))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected <2020-01-02T03:04:05Z> ± 1s but was <2020-01-02T03:04:03.5Z> (differs by -1.5s)" ||
+		mock.ErrorMessages[1][0] != "<1577934245(int64)> is not a time.Time" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
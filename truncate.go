@@ -0,0 +1,300 @@
+package asserting
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unicode/utf8"
+)
+
+// Truncation limits the size of the values formatted in failure messages.
+// A zero limit means no limit.
+type Truncation struct {
+	// MaxRunes is the max number of runes of a formatted value. The rest is
+	// replaced with a note like "...(42 more runes)". If 2 strings are compared,
+	// the runes around the first difference are kept instead of the first ones.
+	MaxRunes int
+	// MaxElems is the max number of elements of a slice, array or map formatted.
+	// The rest are replaced with a note like "...(42 more)".
+	MaxElems int
+}
+
+// DefaultTruncation is the Truncation used if SetTruncation is not called.
+var DefaultTruncation = Truncation{MaxRunes: 10000, MaxElems: 1000}
+
+var truncation atomic.Value // Holds Truncation.
+
+// SetTruncation sets the Truncation of the values formatted in the failure messages
+// of all conditions, and returns the previous one. See TB.WithTruncation for
+// overriding it for the assertions through a TB, and TB.WithMaxMessageLen for
+// limiting the failure messages reported through a TB.
+func SetTruncation(t Truncation) Truncation {
+	prev := getTruncation()
+	truncation.Store(t)
+	return prev
+}
+
+func getTruncation() Truncation {
+	if atomic.LoadInt32(&overridden) > 0 {
+		if t, ok := overrides.Load(goroutineID()); ok {
+			return t.(Truncation)
+		}
+	}
+	if t, ok := truncation.Load().(Truncation); ok {
+		return t
+	}
+	return DefaultTruncation
+}
+
+var (
+	overrides  sync.Map // Goroutine ID to the Truncation overriding the global one.
+	overridden int32    // Number of goroutines in overrides.
+)
+
+// overrideTruncation overrides the Truncation of the values formatted by the current
+// goroutine with t, and returns a function restoring it. Failure messages are computed
+// in the goroutine calling the assertion, so the Truncation of a TB applies to the
+// assertions through it only, even if other tests run in parallel.
+func overrideTruncation(t Truncation) (restore func()) {
+	id := goroutineID()
+	prev, ok := overrides.Load(id)
+	overrides.Store(id, t)
+	if !ok {
+		atomic.AddInt32(&overridden, 1)
+	}
+	return func() {
+		if ok {
+			overrides.Store(id, prev)
+			return
+		}
+		overrides.Delete(id)
+		atomic.AddInt32(&overridden, -1)
+	}
+}
+
+// goroutineID returns the ID of the current goroutine, parsed from the header of its
+// stack trace, "goroutine 42 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = b[len("goroutine "):]
+	if i := strings.IndexByte(string(b), ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+// truncateRunes returns s truncated to max runes, and a note of the number of runes
+// truncated, which is empty if s is not truncated.
+func truncateRunes(s string, max int) (truncated string, note string) {
+	if max <= 0 || len(s) <= max {
+		return s, ""
+	}
+	n := utf8.RuneCountInString(s)
+	if n <= max {
+		return s, ""
+	}
+	i := 0
+	for r := 0; r < max; r++ {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return s[:i], msgf("...(%v more runes)", n-max)
+}
+
+// truncateAround is like truncateRunes, but keeps the max runes around the rune at
+// index at, instead of the first ones. head is a note of the number of runes truncated
+// before them, and tail is a note of the number of runes truncated after them.
+func truncateAround(s string, at, max int) (head, truncated, tail string) {
+	if max <= 0 || len(s) <= max {
+		return "", s, ""
+	}
+	offsets := make([]int, 0, len(s)+1) // Byte offsets of runes, and the end.
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	n := len(offsets)
+	if n <= max {
+		return "", s, ""
+	}
+	offsets = append(offsets, len(s))
+	start := at - max/2
+	if start > n-max {
+		start = n - max
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + max
+	if start > 0 {
+		head = msgf("(%v more runes)...", start)
+	}
+	if end < n {
+		tail = msgf("...(%v more runes)", n-end)
+	}
+	return head, s[offsets[start]:offsets[end]], tail
+}
+
+// firstDiffRune returns the index of the first rune that differs in x and y.
+func firstDiffRune(x, y string) int {
+	n := 0
+	for x != "" && y != "" {
+		_, size1 := utf8.DecodeRuneInString(x)
+		_, size2 := utf8.DecodeRuneInString(y)
+		if x[:size1] != y[:size2] {
+			break
+		}
+		x, y = x[size1:], y[size2:]
+		n++
+	}
+	return n
+}
+
+// formatStrings formats strings x and y like formatValue, except that if they are
+// truncated, the runes around the first difference are kept, so that the difference
+// can be seen. ok is false if x or y is not a string, or has a custom formatter.
+func formatStrings(x, y interface{}) (str1, str2 string, ok bool) {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	if vx.Kind() != reflect.String || vy.Kind() != reflect.String {
+		return
+	}
+	if _, custom := customFormat(x); custom {
+		return
+	}
+	if _, custom := customFormat(y); custom {
+		return
+	}
+	max := getTruncation().MaxRunes
+	at := firstDiffRune(vx.String(), vy.String())
+	format := func(s string) string {
+		head, s, tail := truncateAround(s, at, max)
+		return head + fmt.Sprintf("%q", s) + tail
+	}
+	return format(vx.String()), format(vy.String()), true
+}
+
+// formatTruncated formats v, which is not formatted by a custom formatter, like %v, or
+// %q if v is a string, truncated as the current Truncation specifies. If elements of a
+// slice, array or map are truncated, each of the rest is truncated to MaxRunes, instead
+// of the value as a whole.
+func formatTruncated(v interface{}) string {
+	t := getTruncation()
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		s, note := truncateRunes(rv.String(), t.MaxRunes)
+		return fmt.Sprintf("%q", s) + note
+	case reflect.Slice, reflect.Array:
+		if t.MaxElems > 0 && rv.Len() > t.MaxElems {
+			elems := make([]string, 0, t.MaxElems+1)
+			for i := 0; i < t.MaxElems; i++ {
+				elems = append(elems, t.format(rv.Index(i)))
+			}
+			elems = append(elems, msgf("...(%v more)", rv.Len()-t.MaxElems))
+			return "[" + strings.Join(elems, " ") + "]"
+		}
+	case reflect.Map:
+		if t.MaxElems > 0 && rv.Len() > t.MaxElems {
+			entries := make([]string, 0, rv.Len())
+			for iter := rv.MapRange(); iter.Next(); {
+				entries = append(entries, t.format(iter.Key())+":"+t.format(iter.Value()))
+			}
+			sort.Strings(entries)
+			entries = append(entries[:t.MaxElems], msgf("...(%v more)", rv.Len()-t.MaxElems))
+			return "map[" + strings.Join(entries, " ") + "]"
+		}
+	}
+	return t.format(v)
+}
+
+// format formats v, which may be a reflect.Value, like %v, truncated to t.MaxRunes.
+func (t Truncation) format(v interface{}) string {
+	s, note := truncateRunes(fmt.Sprintf("%v", v), t.MaxRunes)
+	return s + note
+}
+
+// maxLenTB is a testing.TB truncating failure messages.
+type maxLenTB struct {
+	testing.TB
+	max int
+}
+
+func (m maxLenTB) unwrap() testing.TB {
+	return m.TB
+}
+
+func (m maxLenTB) truncate(msg string) string {
+	s, note := truncateRunes(msg, m.max)
+	return s + note
+}
+
+func (m maxLenTB) Error(args ...interface{}) {
+	m.TB.Helper()
+	m.TB.Error(m.truncate(fmt.Sprint(args...)))
+}
+
+func (m maxLenTB) Errorf(format string, args ...interface{}) {
+	m.TB.Helper()
+	m.TB.Error(m.truncate(fmt.Sprintf(format, args...)))
+}
+
+func (m maxLenTB) Fatal(args ...interface{}) {
+	m.TB.Helper()
+	m.TB.Fatal(m.truncate(fmt.Sprint(args...)))
+}
+
+func (m maxLenTB) Fatalf(format string, args ...interface{}) {
+	m.TB.Helper()
+	m.TB.Fatal(m.truncate(fmt.Sprintf(format, args...)))
+}
+
+// WithMaxMessageLen returns a TB which reports failures as t does, except that failure
+// messages longer than max runes are truncated, and followed by a note like
+// "...(42 more runes)", overriding the default Truncation for the messages as a whole,
+// such as long diffs. A non-positive max means no limit.
+func (t TB) WithMaxMessageLen(max int) TB {
+	return TB{maxLenTB{TB: t.TB, max: max}}
+}
+
+// truncationTB is a testing.TB whose assertions format values with its Truncation.
+type truncationTB struct {
+	testing.TB
+	truncation Truncation
+}
+
+func (t truncationTB) unwrap() testing.TB {
+	return t.TB
+}
+
+// WithTruncation returns a TB which reports failures as t does, except that values in
+// the failure messages of its assertions are truncated as truncation specifies,
+// overriding the Truncation set by SetTruncation, e.g.
+//
+//	t.WithTruncation(asserting.Truncation{}).AssertEqual(got, want) // No truncation.
+func (t TB) WithTruncation(truncation Truncation) TB {
+	return TB{truncationTB{TB: t.TB, truncation: truncation}}
+}
+
+// truncationOf returns the Truncation of the last TB.WithTruncation which t is
+// created with. ok is false if there is none.
+func truncationOf(t testing.TB) (truncation Truncation, ok bool) {
+	for {
+		switch w := t.(type) {
+		case truncationTB:
+			return w.truncation, true
+		case TB:
+			t = w.TB
+		case wrapper:
+			t = w.unwrap()
+		default:
+			return Truncation{}, false
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package asserting_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/mkch/asserting"
+	"github.com/mkch/asserting/mocktb"
+)
+
+func TestSetTruncation(t1 *testing.T) {
+	prev := SetTruncation(Truncation{MaxRunes: 20, MaxElems: 3})
+	defer SetTruncation(prev)
+	if prev != DefaultTruncation {
+		t1.Fatal(prev)
+	}
+
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertEqual(strings.Repeat("世", 25), "")
+	t.Assert([]int{1, 2, 3, 4, 5}, IsNil())
+	t.Assert(map[string]int{"e": 5, "d": 4, "c": 3, "b": 2, "a": 1}, IsNil())
+	t.Assert([]int{1, 2, 3}, IsNil())
+	t.Assert([]string{strings.Repeat("x", 30)}, IsNil())
+	t.AssertEqual(strings.Repeat("a", 40)+"x"+strings.Repeat("b", 40), strings.Repeat("a", 40)+"y"+strings.Repeat("b", 40))
+	t.Assert([]int{1, 2, 3, 4, 5}, ElementsMatch([]int{1}))
+	if len(mock.ErrorMessages) != 7 ||
+		mock.ErrorMessages[0][0] != `expected <""> but was <"`+strings.Repeat("世", 20)+`"...(5 more runes)>` ||
+		mock.ErrorMessages[1][0] != "expected nil but was <[1 2 3 ...(2 more)]([]int)>" ||
+		mock.ErrorMessages[2][0] != "expected nil but was <map[a:1 b:2 c:3 ...(2 more)](map[string]int)>" ||
+		mock.ErrorMessages[3][0] != "expected nil but was <[1 2 3]([]int)>" ||
+		mock.ErrorMessages[4][0] != "expected nil but was <[xxxxxxxxxxxxxxxxxxx...(12 more runes)([]string)>" ||
+		mock.ErrorMessages[5][0] != `expected <(30 more runes)..."aaaaaaaaaaybbbbbbbbb"...(31 more runes)> but was <(30 more runes)..."aaaaaaaaaaxbbbbbbbbb"...(31 more runes)>` ||
+		!strings.Contains(mock.ErrorMessages[6][0].(string), "[2 3 4 ...(1 more)]") {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	SetTruncation(Truncation{})
+	mock.ErrorMessages = nil
+	t.AssertEqual(strings.Repeat("x", 30), "")
+	if len(mock.ErrorMessages) != 1 || mock.ErrorMessages[0][0] != `expected <""> but was <"`+strings.Repeat("x", 30)+`">` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestWithMaxMessageLen(t1 *testing.T) {
+	mock := mocktb.New(t1)
+	t := NewTB(mock).WithMaxMessageLen(20)
+
+	t.AssertEqual(1, 2)
+	t.AssertEqual(strings.Repeat("x", 30), "")
+	t.Assert(1, Equals(strings.Repeat("y", 30)).SetFatal())
+	if errors := mock.Errors(); len(errors) != 2 ||
+		errors[0] != "expected <2> but was <1>"[:20]+"...(4 more runes)" ||
+		errors[1] != `expected <""> but wa...(36 more runes)` {
+		t1.Fatal(errors)
+	}
+	if fatals := mock.Fatals(); len(fatals) != 1 || fatals[0] != `expected <"yyyyyyyyy...(35 more runes)` {
+		t1.Fatal(fatals)
+	}
+}
+
+func TestWithTruncation(t1 *testing.T) {
+	mock := mocktb.New(t1)
+	t := NewTB(mock)
+	short := t.WithTruncation(Truncation{MaxRunes: 5, MaxElems: 2}).WithContext("ctx")
+
+	short.AssertEqual(strings.Repeat("x", 10), "")
+	short.Assert([]int{1, 2, 3}, IsNil())
+	short.WithTruncation(Truncation{}).AssertEqual(strings.Repeat("x", 10), "")
+	t.AssertEqual(strings.Repeat("x", 10), "")
+	if errors := mock.Errors(); len(errors) != 4 ||
+		errors[0] != `ctx: expected <""> but was <"xxxxx"...(5 more runes)>` ||
+		errors[1] != "ctx: expected nil but was <[1 2 ...(1 more)]([]int)>" ||
+		errors[2] != `ctx: expected <""> but was <"xxxxxxxxxx">` ||
+		errors[3] != `expected <""> but was <"xxxxxxxxxx">` {
+		t1.Fatal(errors)
+	}
+}
@@ -0,0 +1,145 @@
+package asserting
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// Values returns its arguments as a tuple, capturing all the results of a function
+// call with multiple return values, e.g. Values(divmod(7, 2)).
+func Values(v ...interface{}) []interface{} {
+	return v
+}
+
+type equalsTuple struct {
+	expected []interface{}
+}
+
+// EqualsTuple returns a cond which is true if the tested value is a []interface{},
+// such as one returned by Values, of the same length as expected, and each element
+// of it equals to the element of expected at the same position, as Equals tests.
+// The failure message lists the positions which differ.
+func EqualsTuple(expected ...interface{}) cond.Cond {
+	return cond.New(&equalsTuple{expected: expected})
+}
+
+func (c *equalsTuple) Test(v interface{}) bool {
+	tuple, ok := v.([]interface{})
+	if !ok || len(tuple) != len(c.expected) {
+		return false
+	}
+	for i, e := range c.expected {
+		if !Equals(e).Test(tuple[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *equalsTuple) Message(v interface{}) string {
+	tuple, ok := v.([]interface{})
+	if !ok {
+		return msgf("<%[1]v(%[1]T)> is not a tuple", v)
+	}
+	if len(tuple) != len(c.expected) {
+		return msgf("expected %v values but was %v", len(c.expected), len(tuple))
+	}
+	var diffs []string
+	for i, e := range c.expected {
+		if eq := Equals(e); !eq.Test(tuple[i]) {
+			diffs = append(diffs, msgf("position %v: %v", i, eq.Message(tuple[i])))
+		}
+	}
+	return strings.Join(diffs, "\n")
+}
+
+func (c *equalsTuple) Kind() string {
+	return "EqualsTuple"
+}
+
+func (c *equalsTuple) Expected() interface{} {
+	return c.expected
+}
+
+type equalsPairs struct {
+	expected [][2]interface{}
+}
+
+// EqualsPairs returns a cond which is true if the tested value is an ordered sequence
+// of key/value pairs equal to expected, e.g. the pairs of an ordered map, or the
+// headers of a request in the order of iteration. The tested value must be a slice
+// of 2-element arrays, such as [][2]interface{} or [][2]string, or a slice of structs
+// of 2 fields, the key and the value. Keys and values are compared as Equals does.
+// The failure message reports the first pair which differs.
+func EqualsPairs(expected [][2]interface{}) cond.Cond {
+	return cond.New(&equalsPairs{expected: expected})
+}
+
+func (c *equalsPairs) Test(v interface{}) bool {
+	return c.diff(v) == ""
+}
+
+func (c *equalsPairs) Message(v interface{}) string {
+	return c.diff(v)
+}
+
+// diff returns the first difference between v and c.expected, or an empty string
+// if there is none.
+func (c *equalsPairs) diff(v interface{}) string {
+	pairs, ok := toPairs(v)
+	if !ok {
+		return msgf("<%[1]v(%[1]T)> is not a slice of pairs", v)
+	}
+	for i, p := range pairs {
+		if i >= len(c.expected) {
+			return msgf("pair %v: unexpected <%v: %v>", i, formatValue(p[0]), formatValue(p[1]))
+		}
+		e := c.expected[i]
+		if !Equals(e[0]).Test(p[0]) || !Equals(e[1]).Test(p[1]) {
+			return msgf("pair %v: expected <%v: %v> but was <%v: %v>", i,
+				formatValue(e[0]), formatValue(e[1]), formatValue(p[0]), formatValue(p[1]))
+		}
+	}
+	if len(pairs) < len(c.expected) {
+		e := c.expected[len(pairs)]
+		return msgf("pair %v: missing <%v: %v>", len(pairs), formatValue(e[0]), formatValue(e[1]))
+	}
+	return ""
+}
+
+func (c *equalsPairs) Kind() string {
+	return "EqualsPairs"
+}
+
+func (c *equalsPairs) Expected() interface{} {
+	return c.expected
+}
+
+// toPairs converts v, a slice of 2-element arrays or 2-field structs, to pairs.
+// ok is false if v is of other types.
+func toPairs(v interface{}) (pairs [][2]interface{}, ok bool) {
+	s := reflect.ValueOf(v)
+	if s.Kind() != reflect.Slice {
+		return nil, false
+	}
+	elem := s.Type().Elem()
+	switch {
+	case elem.Kind() == reflect.Array && elem.Len() == 2:
+	case elem.Kind() == reflect.Struct && elem.NumField() == 2 &&
+		elem.Field(0).IsExported() && elem.Field(1).IsExported():
+	default:
+		return nil, false
+	}
+	pairs = make([][2]interface{}, s.Len())
+	for i := range pairs {
+		e := s.Index(i)
+		if e.Kind() == reflect.Array {
+			pairs[i] = [2]interface{}{e.Index(0).Interface(), e.Index(1).Interface()}
+		} else {
+			pairs[i] = [2]interface{}{e.Field(0).Interface(), e.Field(1).Interface()}
+		}
+	}
+	return pairs, true
+}
@@ -0,0 +1,72 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func divmod(a, b int) (int, int) {
+	return a / b, a % b
+}
+
+func TestEqualsTuple(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(Values(divmod(7, 2)), EqualsTuple(3, 1))
+	t.Assert(Values(), EqualsTuple())
+	t.Assert(Values(1, "a", nil), EqualsTuple(1, "a", nil))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(Values(divmod(7, 2)), EqualsTuple(3, 2))
+	t.Assert(Values(1, 2, 3), EqualsTuple(0, 2, "3"))
+	t.Assert(Values(1, 2), EqualsTuple(1, 2, 3))
+	t.Assert(1, EqualsTuple(1))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "position 1: expected <2> but was <1>" ||
+		mock.ErrorMessages[1][0] != "position 0: expected <0> but was <1>\nposition 2: expected <\"3\"> but was <3>" ||
+		mock.ErrorMessages[2][0] != "expected 3 values but was 2" ||
+		mock.ErrorMessages[3][0] != "<1(int)> is not a tuple" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestEqualsPairs(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	type entry struct {
+		Key   string
+		Value int
+	}
+	t.Assert([][2]interface{}{{"a", 1}, {"b", 2}}, EqualsPairs([][2]interface{}{{"a", 1}, {"b", 2}}))
+	t.Assert([][2]string{{"b", "2"}, {"a", "1"}}, EqualsPairs([][2]interface{}{{"b", "2"}, {"a", "1"}}))
+	t.Assert([]entry{{"x", 1}}, EqualsPairs([][2]interface{}{{"x", 1}}))
+	t.Assert([]entry(nil), EqualsPairs(nil))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert([][2]string{{"a", "1"}, {"b", "2"}}, EqualsPairs([][2]interface{}{{"b", "2"}, {"a", "1"}}))
+	t.Assert([]entry{{"x", 1}, {"y", 2}}, EqualsPairs([][2]interface{}{{"x", 1}}))
+	t.Assert([]entry{{"x", 1}}, EqualsPairs([][2]interface{}{{"x", 1}, {"y", 2}}))
+	t.Assert(map[string]int{"x": 1}, EqualsPairs([][2]interface{}{{"x", 1}}))
+	t.Assert([]int{1}, EqualsPairs([][2]interface{}{{"x", 1}}))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != `pair 0: expected <"b": "2"> but was <"a": "1">` ||
+		mock.ErrorMessages[1][0] != `pair 1: unexpected <"y": 2>` ||
+		mock.ErrorMessages[2][0] != `pair 1: missing <"y": 2>` ||
+		mock.ErrorMessages[3][0] != "<map[x:1](map[string]int)> is not a slice of pairs" ||
+		mock.ErrorMessages[4][0] != "<[1]([]int)> is not a slice of pairs" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,314 @@
+package asserting
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type isKind struct {
+	expected reflect.Kind
+}
+
+// IsKind returns a cond which is true if the reflect.Kind of a value is k.
+// The kind of nil is reflect.Invalid.
+func IsKind(k reflect.Kind) cond.Cond {
+	return cond.New(&isKind{expected: k})
+}
+
+func (c *isKind) Test(v interface{}) bool {
+	return reflect.ValueOf(v).Kind() == c.expected
+}
+
+func (c *isKind) Message(v interface{}) string {
+	return msgf("expected kind <%v> but was <%v> <%v(%T)>", c.expected, reflect.ValueOf(v).Kind(), formatValue(v), v)
+}
+
+func (c *isKind) Kind() string {
+	return "IsKind"
+}
+
+func (c *isKind) Expected() interface{} {
+	return c.expected
+}
+
+// typeOf returns example if it is a reflect.Type, the type of example otherwise.
+func typeOf(example interface{}) reflect.Type {
+	if t, ok := example.(reflect.Type); ok {
+		return t
+	}
+	return reflect.TypeOf(example)
+}
+
+type assignableTo struct {
+	expected reflect.Type
+}
+
+// AssignableTo returns a cond which is true if the type of a value is assignable to
+// the type of example. If example is a reflect.Type, it is the type itself. Use
+// reflect.TypeOf((*I)(nil)).Elem() to denote interface type I.
+// The cond is false if the tested value is nil.
+func AssignableTo(example interface{}) cond.Cond {
+	return cond.New(&assignableTo{expected: typeOf(example)})
+}
+
+func (c *assignableTo) Test(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	return t != nil && t.AssignableTo(c.expected)
+}
+
+func (c *assignableTo) Message(v interface{}) string {
+	return msgf("type <%T> is not assignable to <%v>", v, c.expected)
+}
+
+func (c *assignableTo) Kind() string {
+	return "AssignableTo"
+}
+
+func (c *assignableTo) Expected() interface{} {
+	return c.expected
+}
+
+type convertibleTo assignableTo
+
+// ConvertibleTo returns a cond which is true if the type of a value is convertible to
+// the type of example. See AssignableTo for the meaning of example.
+// The cond is false if the tested value is nil.
+func ConvertibleTo(example interface{}) cond.Cond {
+	return cond.New(&convertibleTo{expected: typeOf(example)})
+}
+
+func (c *convertibleTo) Test(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	return t != nil && t.ConvertibleTo(c.expected)
+}
+
+func (c *convertibleTo) Message(v interface{}) string {
+	return msgf("type <%T> is not convertible to <%v>", v, c.expected)
+}
+
+func (c *convertibleTo) Kind() string {
+	return "ConvertibleTo"
+}
+
+func (c *convertibleTo) Expected() interface{} {
+	return c.expected
+}
+
+type isType struct {
+	expected reflect.Type
+}
+
+// IsType returns a cond which is true if the dynamic type of a value is exactly the type
+// of example. See AssignableTo for the meaning of example.
+// The cond is false if the tested value is nil.
+func IsType(example interface{}) cond.Cond {
+	return cond.New(&isType{expected: typeOf(example)})
+}
+
+// IsTypeOf returns a cond which is like IsType, with the type T. Since dynamic types are
+// never interface types, the cond is always false if T is an interface type, use
+// Implements instead.
+func IsTypeOf[T any]() cond.Cond {
+	return cond.New(&isType{expected: reflect.TypeOf((*T)(nil)).Elem()})
+}
+
+func (c *isType) Test(v interface{}) bool {
+	return reflect.TypeOf(v) == c.expected
+}
+
+func (c *isType) Message(v interface{}) string {
+	return msgf("expected type <%v> but was <%T>", c.expected, v)
+}
+
+func (c *isType) Kind() string {
+	return "IsType"
+}
+
+func (c *isType) Expected() interface{} {
+	return c.expected
+}
+
+type implements struct {
+	iface reflect.Type
+}
+
+// Implements returns a cond which is true if the type of a value implements the
+// interface type pointed to by iface, e.g. Implements((*io.Reader)(nil)).
+// The cond is false if the tested value is nil.
+// Implements panics if iface is not a pointer to interface.
+func Implements(iface interface{}) cond.Cond {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a pointer to interface", iface))
+	}
+	return cond.New(&implements{iface: t.Elem()})
+}
+
+func (c *implements) Test(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	return t != nil && t.Implements(c.iface)
+}
+
+func (c *implements) Message(v interface{}) string {
+	return msgf("type <%T> does not implement <%v>", v, c.iface)
+}
+
+func (c *implements) Kind() string {
+	return "Implements"
+}
+
+func (c *implements) Expected() interface{} {
+	return c.iface
+}
+
+type hasField struct {
+	name  string
+	conds []cond.Cond
+}
+
+// HasField returns a cond which is true if a struct, or a pointer to struct, has an exported
+// field with the given name, including fields promoted from embedded structs.
+// If any cond is provided, the value of the field must also meet all of them.
+func HasField(name string, conds ...cond.Cond) cond.Cond {
+	return cond.New(&hasField{name: name, conds: conds})
+}
+
+func (c *hasField) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *hasField) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *hasField) Kind() string {
+	return "HasField"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *hasField) Check(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return msgf("<%[1]v(%[1]T)> is not a struct", v)
+	}
+	f, ok := rv.Type().FieldByName(c.name)
+	if !ok || f.PkgPath != "" {
+		return msgf("type <%v> has no exported field %v", rv.Type(), c.name)
+	}
+	fv, err := rv.FieldByIndexErr(f.Index)
+	if err != nil { // Through a nil embedded pointer.
+		return msgf("field %v of <%v>: %v", c.name, rv.Type(), err)
+	}
+	for _, fc := range c.conds {
+		if ok, msg := cond.Check(fc, fv.Interface()); !ok {
+			return msgf("field %v of <%v>: %v", c.name, rv.Type(), msg)
+		}
+	}
+	return ""
+}
+
+type hasMethod struct {
+	name      string
+	signature reflect.Type // nil if any signature is acceptable.
+}
+
+// HasMethod returns a cond which is true if the method set of the type of a value
+// has an exported method with the given name. Note that methods with pointer receivers
+// are not in the method set of the value type.
+func HasMethod(name string) cond.Cond {
+	return cond.New(&hasMethod{name: name})
+}
+
+// HasMethodWithSignature is like HasMethod, except that the method must also
+// have the signature of example, which is a func value, or a reflect.Type of func,
+// without the receiver. For example:
+//
+//	HasMethodWithSignature("Read", func([]byte) (int, error) { return 0, nil })
+func HasMethodWithSignature(name string, example interface{}) cond.Cond {
+	return cond.New(&hasMethod{name: name, signature: typeOf(example)})
+}
+
+func (c *hasMethod) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *hasMethod) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *hasMethod) Kind() string {
+	if c.signature != nil {
+		return "HasMethodWithSignature"
+	}
+	return "HasMethod"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *hasMethod) Check(v interface{}) string {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return msgf("nil has no method %v", c.name)
+	}
+	m := reflect.ValueOf(v).MethodByName(c.name)
+	if !m.IsValid() {
+		return msgf("type <%v> has no method %v", t, c.name)
+	}
+	if c.signature != nil && m.Type() != c.signature {
+		return msgf("method %v of type <%v> is <%v>, not <%v>", c.name, t, m.Type(), c.signature)
+	}
+	return ""
+}
+
+type fieldTag struct {
+	field, key string
+	c          cond.Cond
+}
+
+// FieldTag returns a cond which is true if a struct, or a pointer to struct, has a field
+// with the given name, the tag of which has the given key, e.g. "json",
+// and the value associated with the key meets c. For example:
+//
+//	FieldTag("ID", "json", Equals("id,omitempty"))
+func FieldTag(field, key string, c cond.Cond) cond.Cond {
+	return cond.New(&fieldTag{field: field, key: key, c: c})
+}
+
+func (c *fieldTag) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *fieldTag) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+func (c *fieldTag) Kind() string {
+	return "FieldTag"
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *fieldTag) Check(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return msgf("<%[1]v(%[1]T)> is not a struct", v)
+	}
+	f, ok := t.FieldByName(c.field)
+	if !ok {
+		return msgf("type <%v> has no field %v", t, c.field)
+	}
+	tag, ok := f.Tag.Lookup(c.key)
+	if !ok {
+		return msgf("field %v of <%v> has no %v tag", c.field, t, c.key)
+	}
+	if ok, msg := cond.Check(c.c, tag); !ok {
+		return msgf("%v tag of field %v of <%v>: %v", c.key, c.field, t, msg)
+	}
+	return ""
+}
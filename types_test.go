@@ -0,0 +1,219 @@
+package asserting_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestIsKind(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(&struct{}{}, IsKind(reflect.Ptr))
+	t.Assert(map[int]int(nil), IsKind(reflect.Map))
+	t.Assert(nil, IsKind(reflect.Invalid))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(1, IsKind(reflect.Ptr))
+	t.Assert("a", IsKind(reflect.Slice))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "expected kind <ptr> but was <int> <1(int)>" ||
+		mock.ErrorMessages[1][0] != `expected kind <slice> but was <string> <"a"(string)>` {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type MyInt int
+
+func TestAssignableConvertibleTo(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(&bytes.Buffer{}, AssignableTo(reflect.TypeOf((*io.Reader)(nil)).Elem()))
+	t.Assert(1, AssignableTo(0))
+	t.Assert(MyInt(1), ConvertibleTo(0))
+	t.Assert(1, ConvertibleTo(reflect.TypeOf(1.0)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(MyInt(1), AssignableTo(0))
+	t.Assert(nil, AssignableTo(reflect.TypeOf((*error)(nil)).Elem()))
+	t.Assert("a", ConvertibleTo(1.0))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "type <asserting_test.MyInt> is not assignable to <int>" ||
+		mock.ErrorMessages[1][0] != "type <<nil>> is not assignable to <error>" ||
+		mock.ErrorMessages[2][0] != "type <string> is not convertible to <float64>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type Base struct {
+	ID int
+}
+
+type Entity struct {
+	*Base
+	Name  string
+	token string
+}
+
+func TestHasField(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(Entity{}, HasField("Name"))
+	t.Assert(&Entity{Base: &Base{ID: 1}}, HasField("ID", Equals(1)))
+	t.Assert(Entity{Name: "a"}, HasField("Name", NotEquals(""), Equals("a")))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(Entity{}, HasField("token"))
+	t.Assert(Entity{}, HasField("Age"))
+	t.Assert(Entity{Name: "a"}, HasField("Name", Equals("b")))
+	t.Assert(Entity{}, HasField("ID", Equals(0)))
+	t.Assert(1, HasField("ID"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != "type <asserting_test.Entity> has no exported field token" ||
+		mock.ErrorMessages[1][0] != "type <asserting_test.Entity> has no exported field Age" ||
+		mock.ErrorMessages[2][0] != `field Name of <asserting_test.Entity>: expected <"b"> but was <"a">` ||
+		mock.ErrorMessages[3][0] != "field ID of <asserting_test.Entity>: reflect: indirection through nil pointer to embedded struct field Base" ||
+		mock.ErrorMessages[4][0] != "<1(int)> is not a struct" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func (e Entity) Token() string {
+	return e.token
+}
+
+func (e *Entity) SetToken(token string) {
+	e.token = token
+}
+
+func TestHasMethod(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(Entity{}, HasMethod("Token"))
+	t.Assert(&Entity{}, HasMethod("SetToken"))
+	t.Assert(&Entity{}, HasMethodWithSignature("Token", func() string { return "" }))
+	t.Assert(&bytes.Buffer{}, HasMethodWithSignature("Read", reflect.TypeOf((*io.Reader)(nil)).Elem().Method(0).Type))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(Entity{}, HasMethod("SetToken"))
+	t.Assert(&Entity{}, HasMethodWithSignature("SetToken", func() {}))
+	t.Assert(nil, HasMethod("String"))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "type <asserting_test.Entity> has no method SetToken" ||
+		mock.ErrorMessages[1][0] != "method SetToken of type <*asserting_test.Entity> is <func(string)>, not <func()>" ||
+		mock.ErrorMessages[2][0] != "nil has no method String" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+type Record struct {
+	ID   int    `json:"id,omitempty" db:"id"`
+	Name string `json:"name"`
+}
+
+func TestFieldTag(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(Record{}, FieldTag("ID", "json", Equals("id,omitempty")))
+	t.Assert(&Record{}, FieldTag("ID", "db", Equals("id")))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(Record{}, FieldTag("Name", "json", Equals("Name")))
+	t.Assert(Record{}, FieldTag("Name", "db", Equals("name")))
+	t.Assert(Record{}, FieldTag("Age", "db", Equals("age")))
+	t.Assert(nil, FieldTag("Age", "db", Equals("age")))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != `json tag of field Name of <asserting_test.Record>: expected <"Name"> but was <"name">` ||
+		mock.ErrorMessages[1][0] != "field Name of <asserting_test.Record> has no db tag" ||
+		mock.ErrorMessages[2][0] != "type <asserting_test.Record> has no field Age" ||
+		mock.ErrorMessages[3][0] != "<<nil>(<nil>)> is not a struct" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestIsType(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	var r io.Reader = &bytes.Buffer{}
+	t.Assert(r, IsType(&bytes.Buffer{}))
+	t.Assert(1, IsType(0))
+	t.Assert(MyInt(1), IsType(reflect.TypeOf(MyInt(0))))
+	t.Assert(r, IsTypeOf[*bytes.Buffer]())
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(MyInt(1), IsType(0))
+	t.Assert(nil, IsTypeOf[int]())
+	t.Assert(r, IsTypeOf[io.Reader]())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "expected type <int> but was <asserting_test.MyInt>" ||
+		mock.ErrorMessages[1][0] != "expected type <int> but was <<nil>>" ||
+		mock.ErrorMessages[2][0] != "expected type <io.Reader> but was <*bytes.Buffer>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestImplements(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.Assert(&bytes.Buffer{}, Implements((*io.Reader)(nil)))
+	t.Assert(&nilError{}, Implements((*error)(nil)))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(bytes.Buffer{}, Implements((*io.Reader)(nil)))
+	t.Assert(nil, Implements((*error)(nil)))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 2 ||
+		mock.ErrorMessages[0][0] != "type <bytes.Buffer> does not implement <io.Reader>" ||
+		mock.ErrorMessages[1][0] != "type <<nil>> does not implement <error>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(func() { Implements(io.EOF) }, Panics("<EOF(*errors.errorString)> is not a pointer to interface"))
+	if len(mock.ErrorMessages) != 2 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
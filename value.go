@@ -0,0 +1,135 @@
+package asserting
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mkch/asserting/cond"
+)
+
+type isZero struct {
+	zero bool // Whether the tested value is expected to be zero.
+}
+
+// IsZero returns a cond which is true if a value is the zero value of its type,
+// as reflect.Value.IsZero reports, e.g. a freshly constructed struct.
+// A nil interface value is a zero value.
+func IsZero() cond.Cond {
+	return cond.New(&isZero{zero: true})
+}
+
+// NotZero returns a cond which is true if a value is not the zero value of its type,
+// e.g. an ID was populated or a timestamp was set. See IsZero.
+func NotZero() cond.Cond {
+	return cond.New(&isZero{zero: false})
+}
+
+// NotZeroValue returns a cond which is true if a value is not the zero value of its
+// type. It is the same as NotZero.
+func NotZeroValue() cond.Cond {
+	return NotZero()
+}
+
+func (c *isZero) Test(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return (!rv.IsValid() || rv.IsZero()) == c.zero
+}
+
+func (c *isZero) Message(v interface{}) string {
+	if c.zero {
+		return msgf("expected zero value but was <%v(%T)>", formatValue(v), v)
+	}
+	return msgf("unexpected zero value <%v(%T)>", formatValue(v), v)
+}
+
+func (c *isZero) Kind() string {
+	if c.zero {
+		return "IsZero"
+	}
+	return "NotZero"
+}
+
+type isNil struct {
+	isNil bool // Whether the tested value is expected to be nil.
+}
+
+// IsNil returns a cond which is true if a value is a nil interface value, or a nil
+// pointer, slice, map, channel, func or unsafe.Pointer, as Equals(nil) tests.
+// Note that the latter, when stored in an interface such as error, makes the interface
+// value itself non-nil, see EqualsStrictly.
+func IsNil() cond.Cond {
+	return cond.New(&isNil{isNil: true})
+}
+
+// NotNil returns a cond which is true if a value is not nil, see IsNil.
+func NotNil() cond.Cond {
+	return cond.New(&isNil{isNil: false})
+}
+
+func (c *isNil) Test(v interface{}) bool {
+	return equalsNil(v) == c.isNil
+}
+
+func (c *isNil) Message(v interface{}) string {
+	if c.isNil {
+		return msgf("expected nil but was <%v(%T)>", formatValue(v), v)
+	}
+	if v != nil {
+		return msgf("unexpected typed nil <%T>; an interface holding it is not nil", v)
+	}
+	return msgf("unexpected nil")
+}
+
+func (c *isNil) Kind() string {
+	if c.isNil {
+		return "IsNil"
+	}
+	return "NotNil"
+}
+
+type same struct {
+	expected interface{}
+}
+
+// Same returns a cond which is true if a value and expected are of the same type, and
+// refer to the same object, as opposed to equal objects. Both must be pointers, maps,
+// channels, funcs or unsafe.Pointers. Two funcs are the same if they have the same code.
+// Same panics if expected is of other kinds.
+func Same(expected interface{}) cond.Cond {
+	if !isReference(reflect.ValueOf(expected)) {
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a pointer, map, channel or func", expected))
+	}
+	return cond.New(&same{expected: expected})
+}
+
+func (c *same) Test(v interface{}) bool {
+	x, y := reflect.ValueOf(c.expected), reflect.ValueOf(v)
+	return isReference(y) && x.Type() == y.Type() && x.Pointer() == y.Pointer()
+}
+
+func (c *same) Message(v interface{}) string {
+	y := reflect.ValueOf(v)
+	if !isReference(y) {
+		return msgf("<%[1]v(%[1]T)> is not a pointer, map, channel or func", v)
+	}
+	return msgf("expected <%#x(%T)> but was <%#x(%T)>",
+		reflect.ValueOf(c.expected).Pointer(), c.expected, y.Pointer(), v)
+}
+
+func (c *same) Kind() string {
+	return "Same"
+}
+
+func (c *same) Expected() interface{} {
+	return c.expected
+}
+
+// isReference returns whether v is a pointer, map, channel, func or unsafe.Pointer.
+func isReference(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,143 @@
+package asserting_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestNotZeroValue(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	t.Assert(1, NotZeroValue())
+	t.Assert(user{Name: "a"}, NotZeroValue())
+	t.Assert([]int{}, NotZeroValue())
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(0, NotZeroValue())
+	t.Assert(user{}, NotZeroValue())
+	t.Assert(time.Time{}, NotZeroValue())
+	t.Assert(nil, NotZeroValue())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "unexpected zero value <0(int)>" ||
+		mock.ErrorMessages[1][0] != "unexpected zero value <{0 }(asserting_test.user)>" ||
+		mock.ErrorMessages[2][0] != "unexpected zero value <0001-01-01 00:00:00 +0000 UTC(time.Time)>" ||
+		mock.ErrorMessages[3][0] != "unexpected zero value <<nil>(<nil>)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestIsZero(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	type config struct {
+		Port    int
+		Timeout time.Duration
+	}
+
+	t.Assert(config{}, IsZero())
+	t.Assert("", IsZero())
+	t.Assert([]int(nil), IsZero())
+	t.Assert(nil, IsZero())
+	t.Assert(config{Port: 80}, NotZero())
+	t.Assert([]int{}, NotZero())
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(config{Timeout: time.Second}, IsZero())
+	t.Assert(" ", IsZero())
+	t.Assert(config{}, NotZero())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != "expected zero value but was <{0 1s}(asserting_test.config)>" ||
+		mock.ErrorMessages[1][0] != `expected zero value but was <" "(string)>` ||
+		mock.ErrorMessages[2][0] != "unexpected zero value <{0 0s}(asserting_test.config)>" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestIsNil(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	var err error
+	var nilErr *nilError
+	t.Assert(nil, IsNil())
+	t.Assert(err, IsNil())
+	t.Assert(nilErr, IsNil())
+	t.Assert([]int(nil), IsNil())
+	t.Assert(map[int]int(nil), IsNil())
+	t.Assert((func())(nil), IsNil())
+	t.Assert(1, NotNil())
+	t.Assert([]int{}, NotNil())
+	t.Assert(&nilError{}, NotNil())
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	err = nilErr
+	t.Assert(0, IsNil())
+	t.Assert([]int{}, IsNil())
+	t.Assert(nil, NotNil())
+	t.Assert(err, NotNil())
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 4 ||
+		mock.ErrorMessages[0][0] != "expected nil but was <0(int)>" ||
+		mock.ErrorMessages[1][0] != "expected nil but was <[]([]int)>" ||
+		mock.ErrorMessages[2][0] != "unexpected nil" ||
+		mock.ErrorMessages[3][0] != "unexpected typed nil <*asserting_test.nilError>; an interface holding it is not nil" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
+
+func TestSame(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	p, q := new(int), new(int)
+	m := map[int]int{}
+	t.Assert(p, Same(p))
+	t.Assert(m, Same(m))
+	t.Assert(TestSame, Same(TestSame))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(q, Same(p))
+	t.Assert(map[int]int{}, Same(m))
+	t.Assert(1, Same(p))
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 3 ||
+		mock.ErrorMessages[0][0] != fmt.Sprintf("expected <%p(*int)> but was <%p(*int)>", p, q) ||
+		!strings.HasSuffix(mock.ErrorMessages[1][0].(string), "(map[int]int)>") ||
+		mock.ErrorMessages[2][0] != "<1(int)> is not a pointer, map, channel or func" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.Assert(func() { Same(1) }, Panics("<1(int)> is not a pointer, map, channel or func"))
+	if len(mock.ErrorMessages) != 3 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
@@ -0,0 +1,217 @@
+package asserting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mkch/asserting/cond"
+)
+
+// xmlNode is an XML element.
+type xmlNode struct {
+	name     string
+	attrs    map[string]string
+	text     string // Character data with insignificant whitespace trimmed.
+	children []*xmlNode
+}
+
+// xmlName formats name as "local", or "{space}local" if name is in a namespace.
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// parseXML parses the XML document s into its root element. Comments, processing
+// instructions and directives are ignored.
+func parseXML(s string) (*xmlNode, error) {
+	d := xml.NewDecoder(strings.NewReader(s))
+	var root *xmlNode
+	var stack []*xmlNode
+	var texts [][]string // Character data of the elements in stack.
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if root != nil && len(stack) == 0 {
+				return nil, fmt.Errorf("more than one root element")
+			}
+			node := &xmlNode{name: xmlName(tok.Name), attrs: make(map[string]string)}
+			for _, attr := range tok.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					continue // Namespaces are compared as parts of names.
+				}
+				node.attrs[xmlName(attr.Name)] = attr.Value
+			}
+			if len(stack) == 0 {
+				root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			}
+			stack = append(stack, node)
+			texts = append(texts, nil)
+		case xml.EndElement:
+			node := stack[len(stack)-1]
+			node.text = strings.Join(texts[len(texts)-1], " ")
+			stack, texts = stack[:len(stack)-1], texts[:len(texts)-1]
+		case xml.CharData:
+			text := strings.TrimSpace(string(tok))
+			if text == "" {
+				continue
+			}
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("character data outside the root element")
+			}
+			texts[len(texts)-1] = append(texts[len(texts)-1], text)
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no root element")
+	}
+	return root, nil
+}
+
+// xmlDiffer collects the differences between XML elements.
+type xmlDiffer struct {
+	diffs []string
+}
+
+func (d *xmlDiffer) report(path, format string, args ...interface{}) {
+	d.diffs = append(d.diffs, path+": "+msgf(format, args...))
+}
+
+func (d *xmlDiffer) walk(path string, x, y *xmlNode) {
+	if len(d.diffs) > maxDiffs {
+		return
+	}
+	if x.name != y.name {
+		d.report(path, "expected element <%v> but was <%v>", x.name, y.name)
+		return
+	}
+	names := make([]string, 0, len(x.attrs))
+	for name := range x.attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if v, ok := y.attrs[name]; !ok {
+			d.report(path+"/@"+name, "missing")
+		} else if v != x.attrs[name] {
+			d.report(path+"/@"+name, "expected <%v> but was <%v>", x.attrs[name], v)
+		}
+	}
+	names = names[:0]
+	for name := range y.attrs {
+		if _, ok := x.attrs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		d.report(path+"/@"+name, "unexpected <%v>", y.attrs[name])
+	}
+	if x.text != y.text {
+		d.report(path+"/text()", "expected <%v> but was <%v>", x.text, y.text)
+	}
+	if len(x.children) != len(y.children) {
+		d.report(path, "expected %v child elements but was %v", len(x.children), len(y.children))
+		return
+	}
+	count := make(map[string]int)
+	for _, child := range x.children {
+		count[child.name]++
+	}
+	seen := make(map[string]int)
+	for i, child := range x.children {
+		seen[child.name]++
+		p := path + "/" + child.name
+		if count[child.name] > 1 {
+			p += fmt.Sprintf("[%v]", seen[child.name])
+		}
+		d.walk(p, child, y.children[i])
+	}
+}
+
+// diffXML returns the differences between expected and actual, one per line, or an
+// empty string if none.
+func diffXML(expected, actual *xmlNode) string {
+	var d xmlDiffer
+	d.walk("/"+expected.name, expected, actual)
+	if len(d.diffs) > maxDiffs {
+		d.diffs = append(d.diffs[:maxDiffs], "...(more differences)")
+	}
+	return strings.Join(d.diffs, "\n")
+}
+
+type xmlEq struct {
+	expected       string
+	expectedParsed *xmlNode
+}
+
+// XMLEq returns a cond which is true if the tested XML document equals to expected
+// structurally: elements have the same names, attributes and text, and their child
+// elements are equal in order. The order of attributes, namespace prefixes, comments
+// and the whitespace around text are ignored. Both documents are strings or []byte.
+// The failure message lists the node path of every difference, e.g.
+// "/catalog/book[2]/@id: expected <b2> but was <b3>".
+// XMLEq panics if expected is not a valid XML document.
+func XMLEq(expected interface{}) cond.Cond {
+	s, ok := stringValue(expected)
+	if !ok {
+		panic(fmt.Sprintf("<%[1]v(%[1]T)> is not a string", expected))
+	}
+	parsed, err := parseXML(s)
+	if err != nil {
+		panic(fmt.Sprintf("invalid XML <%v>: %v", s, err))
+	}
+	return cond.New(&xmlEq{expected: s, expectedParsed: parsed})
+}
+
+func (c *xmlEq) Test(v interface{}) bool {
+	return c.Check(v) == ""
+}
+
+func (c *xmlEq) Message(v interface{}) string {
+	return c.Check(v)
+}
+
+// Check returns the failure message, or an empty string if c is met.
+func (c *xmlEq) Check(v interface{}) string {
+	s, ok := stringValue(v)
+	if !ok {
+		return msgf("<%[1]v(%[1]T)> is not a string", v)
+	}
+	parsed, err := parseXML(s)
+	if err != nil {
+		return msgf("invalid XML <%v>: %v", s, err)
+	}
+	if d := diffXML(c.expectedParsed, parsed); d != "" {
+		return msgf("XML differs:\n%v", d)
+	}
+	return ""
+}
+
+func (c *xmlEq) Kind() string {
+	return "XMLEq"
+}
+
+func (c *xmlEq) Expected() interface{} {
+	return c.expected
+}
+
+// AssertXMLEq calls t.Assert(actual, XMLEq(expected)).
+func (t TB) AssertXMLEq(actual, expected interface{}) {
+	t.Helper()
+	t.Assert(actual, XMLEq(expected))
+}
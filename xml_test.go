@@ -0,0 +1,45 @@
+package asserting_test
+
+import (
+	"testing"
+
+	. "github.com/mkch/asserting"
+)
+
+func TestAssertXMLEq(t1 *testing.T) {
+	mock := &MockTB{TB: t1}
+	t := NewTB(mock)
+
+	t.AssertXMLEq(`<?xml version="1.0"?>
+<catalog xmlns:x="urn:x">
+	<!-- books -->
+	<book lang="en" id="b1">  Go  </book>
+	<x:book id="b2"/>
+</catalog>`, []byte(`<catalog xmlns:y="urn:x"><book id="b1" lang="en">Go</book><y:book id="b2"></y:book></catalog>`))
+	if len(mock.ErrorMessages) != 0 || len(mock.FatalMessages) != 0 {
+		t1.Fatal(mock.ErrorMessages)
+	}
+
+	t.AssertXMLEq(`<catalog><book id="b1" lang="fr">Go</book><book id="b3" x="1">C</book><cd/></catalog>`,
+		`<catalog><book id="b1">Go</book><book id="b2">Rust</book><dvd/></catalog>`)
+	t.AssertXMLEq(`<a><b/></a>`, `<a><b/><b/></a>`)
+	t.AssertXMLEq(`<a>`, `<a/>`)
+	t.AssertXMLEq(`<a/><b/>`, `<a/>`)
+	t.AssertXMLEq(1, `<a/>`)
+	if len(mock.FatalMessages) != 0 {
+		t1.Fatal()
+	}
+	if len(mock.ErrorMessages) != 5 ||
+		mock.ErrorMessages[0][0] != "XML differs:\n"+
+			"/catalog/book[1]/@lang: unexpected <fr>\n"+
+			"/catalog/book[2]/@id: expected <b2> but was <b3>\n"+
+			"/catalog/book[2]/@x: unexpected <1>\n"+
+			"/catalog/book[2]/text(): expected <Rust> but was <C>\n"+
+			"/catalog/dvd: expected element <dvd> but was <cd>" ||
+		mock.ErrorMessages[1][0] != "XML differs:\n/a: expected 2 child elements but was 1" ||
+		mock.ErrorMessages[2][0] != "invalid XML <<a>>: XML syntax error on line 1: unexpected EOF" ||
+		mock.ErrorMessages[3][0] != "invalid XML <<a/><b/>>: more than one root element" ||
+		mock.ErrorMessages[4][0] != "<1(int)> is not a string" {
+		t1.Fatal(mock.ErrorMessages)
+	}
+}
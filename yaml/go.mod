@@ -0,0 +1,12 @@
+module github.com/mkch/asserting/yaml
+
+go 1.21
+
+require (
+	github.com/mkch/asserting v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require github.com/google/go-cmp v0.7.0 // indirect
+
+replace github.com/mkch/asserting => ../
@@ -0,0 +1,53 @@
+// Package yaml provides the conds of YAML documents. It is a module of its own, so that
+// the asserting module does not depend on the YAML parser.
+package yaml
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/mkch/asserting"
+	"github.com/mkch/asserting/cond"
+	"gopkg.in/yaml.v3"
+)
+
+// Eq returns a cond which is true if the tested YAML document equals to expected
+// semantically, as they are parsed, ignoring formatting, comments and the order of
+// mapping keys. Both documents are strings or []byte, and may be streams of multiple
+// documents, e.g. Kubernetes manifests, which are compared document by document.
+// The failure message lists the path of every difference, e.g.
+// `["spec"]["replicas"]: expected <3> but was <2>`, in which the index of the document
+// comes first, e.g. "[1]", if the stream has more than one document.
+// Eq panics if expected is not valid YAML.
+func Eq(expected interface{}) cond.Cond {
+	return asserting.DocumentEq("YAML", parse, expected)
+}
+
+// parse parses the YAML documents in s. The result is the only document if s has
+// only one, or a []interface{} of all the documents.
+func parse(s string) (interface{}, error) {
+	var docs []interface{}
+	d := yaml.NewDecoder(strings.NewReader(s))
+	for {
+		var doc interface{}
+		err := d.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	if len(docs) == 1 {
+		return docs[0], nil
+	}
+	return docs, nil
+}
+
+// AssertEq calls t.Assert(actual, Eq(expected)).
+func AssertEq(t asserting.TB, actual, expected interface{}) {
+	t.Helper()
+	t.Assert(actual, Eq(expected))
+}
@@ -0,0 +1,40 @@
+package yaml_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mkch/asserting"
+	"github.com/mkch/asserting/cond"
+	"github.com/mkch/asserting/mocktb"
+	. "github.com/mkch/asserting/yaml"
+)
+
+func TestAssertEq(t1 *testing.T) {
+	mock := mocktb.New(t1)
+	t := asserting.NewTB(mock)
+
+	AssertEq(t, "kind: Pod\nspec: {replicas: 3, ports: [80]} # comment\n", []byte("spec:\n  ports:\n  - 80\n  replicas: 3\nkind: Pod\n"))
+	AssertEq(t, "a: 1\n---\nb: 2\n", "{a: 1}\n---\n{b: 2}")
+	if mock.Failed() {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+
+	AssertEq(t, "kind: Pod\nspec: {replicas: 2, ports: ['80']}\n", "kind: Pod\nspec: {replicas: 3, ports: [80]}\n")
+	AssertEq(t, "a: 1\n---\nb: 3\n", "a: 1\n---\nb: 2\n")
+	AssertEq(t, "a: [", "a: 1")
+	AssertEq(t, 1, "1")
+	if len(mock.Fatals()) != 0 || !reflect.DeepEqual(mock.Errors(), []string{
+		"YAML differs:\n" +
+			`["spec"]["ports"][0]: expected <80(int)> but was <"80"(string)>` + "\n" +
+			`["spec"]["replicas"]: expected <3> but was <2>`,
+		"YAML differs:\n" + `[1]["b"]: expected <2> but was <3>`,
+		"invalid YAML <a: [>: yaml: line 1: did not find expected node content",
+		"<1(int)> is not a string",
+	}) {
+		t1.Fatal(mock.Errors(), mock.Fatals())
+	}
+	if kind := cond.Kind(Eq("a: 1")); kind != "YAMLEq" {
+		t1.Fatal(kind)
+	}
+}